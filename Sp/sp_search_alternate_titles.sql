@@ -0,0 +1,56 @@
+USE INTEGRASI_DB
+GO
+
+-- ============================================================================
+-- Full-text index untuk alternate_titles (judul lokal / alternatif)
+-- Memakai catalog yang sama dengan titles (FTCatalog_Titles)
+-- ============================================================================
+CREATE FULLTEXT INDEX ON alternate_titles
+(
+    title LANGUAGE 1033
+)
+KEY INDEX PK_alternate_titles
+ON FTCatalog_Titles
+WITH CHANGE_TRACKING AUTO;
+GO
+
+-- ============================================================================
+-- SP: sp_SearchTitles - Search berdasarkan name, original_name, dan alternate titles
+-- Uses: fnGetFilmCardDetail function
+-- ============================================================================
+CREATE OR ALTER PROCEDURE sp_SearchTitles
+    @keyword NVARCHAR(100)
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    -- Phrase search, tanda kutip di keyword dibuang supaya tidak merusak syntax CONTAINS
+    DECLARE @search NVARCHAR(200) = '"' + REPLACE(@keyword, '"', '') + '"';
+
+    SELECT
+        f.title_id,
+        f.name,
+        f.startYear,
+        f.vote_average,
+        f.vote_count,
+        f.genre_name
+    FROM (
+        SELECT TOP 15 t.title_id, t.vote_count
+        FROM titles t
+        JOIN dbo.FilterTitles() ft ON ft.title_id = t.title_id
+        WHERE CONTAINS((t.name, t.original_name), @search)
+           OR EXISTS (
+                SELECT 1
+                FROM alternate_titles at
+                WHERE at.title_id = t.title_id
+                  AND CONTAINS(at.title, @search)
+           )
+        ORDER BY t.vote_count DESC
+    ) AS results
+    CROSS APPLY dbo.fnGetFilmCardDetail(results.title_id) f
+    ORDER BY results.vote_count DESC;
+END
+GO
+
+-- Test
+EXEC sp_SearchTitles 'breaking bad';
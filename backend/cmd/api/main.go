@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"time"
 
 	"film-dashboard-api/internal/config"
 	"film-dashboard-api/internal/database"
@@ -25,6 +26,11 @@ func main() {
 	}
 	fmt.Printf("✅ Configuration loaded (Environment: %s)\n", cfg.Server.Environment)
 
+	// Client IP untuk rate limiting & analytics: X-Forwarded-For hanya dipercaya dari TRUSTED_PROXIES
+	if err := middleware.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatalf("❌ Failed to load config: %v", err)
+	}
+
 	// 2. Connect to database
 	fmt.Println("📡 Connecting to database...")
 	db, err := database.Connect(cfg.GetConnectionString())
@@ -34,19 +40,54 @@ func main() {
 	defer db.Close()
 	fmt.Println("✅ Database connected!")
 
+	// Jalankan schema migrations (internal/database/migrations)
+	if err := database.Migrate(db); err != nil {
+		log.Fatalf("❌ Failed to run migrations: %v", err)
+	}
+	fmt.Println("✅ Migrations up to date!")
+
 	// 3. Initialize repositories
 	userRepo := repository.NewUserRepository(db)
 	titleRepo := repository.NewTitleRepository(db)
 	reviewRepo := repository.NewReviewRepository(db)
+	personRepo := repository.NewPersonRepository(db)
+	autocompleteRepo := repository.NewAutocompleteRepository(db)
+	watchlistRepo := repository.NewWatchlistRepository(db)
+	userListRepo := repository.NewUserListRepository(db)
+	watchHistoryRepo := repository.NewWatchHistoryRepository(db)
+	moderationRepo := repository.NewModerationRepository(db)
+	analyticsRepo := repository.NewAnalyticsRepository(db)
 
 	// 4. Initialize services
 	authService := service.NewAuthService(userRepo, cfg.JWT.Secret, cfg.JWT.ExpirationHours)
-	reviewService := service.NewReviewService(reviewRepo)
+	contentFilter := service.NewContentFilter(cfg.Review.MaxLength, cfg.Review.BlockedWords, cfg.Review.MaskBlocked)
+	communityScoreService := service.NewCommunityScoreService(titleRepo, cfg.Community.PriorMean, cfg.Community.PriorWeight)
+	communityScoreService.Start()
+	reviewService := service.NewReviewService(reviewRepo, contentFilter, cfg.Review.AllowEpisodes, communityScoreService)
+	searchService := service.NewSearchService(titleRepo)
+	watchlistService := service.NewWatchlistService(watchlistRepo)
+	userListService := service.NewUserListService(userListRepo)
+	watchHistoryService := service.NewWatchHistoryService(watchHistoryRepo)
+	moderationService := service.NewModerationService(moderationRepo, reviewRepo, communityScoreService)
+	trendingService := service.NewTrendingService(titleRepo, service.MaxAnalyticsDays) // Views disimpan selama range analytics
+	trendingService.Start(time.Duration(cfg.Trending.RefreshMinutes) * time.Minute)
+	viewTracker := service.NewViewTracker(analyticsRepo, cfg.Analytics.ViewBufferMaxSize)
+	viewTracker.Start(time.Duration(cfg.Analytics.ViewFlushSeconds) * time.Second)
+	analyticsService := service.NewAnalyticsService(analyticsRepo)
+	autocompleteService := service.NewAutocompleteService(autocompleteRepo)
+	autocompleteService.Start(time.Duration(cfg.Autocomplete.RefreshMinutes) * time.Minute)
 
 	// 5. Initialize handlers
 	authHandler := handler.NewAuthHandler(authService)
-	titleHandler := handler.NewTitleHandler(titleRepo)
-	reviewHandler := handler.NewReviewHandler(reviewService)
+	titleHandler := handler.NewTitleHandler(titleRepo, searchService, trendingService, viewTracker)
+	reviewHandler := handler.NewReviewHandler(reviewService, cfg.Moderation.Roles)
+	personHandler := handler.NewPersonHandler(personRepo)
+	autocompleteHandler := handler.NewAutocompleteHandler(autocompleteService)
+	watchlistHandler := handler.NewWatchlistHandler(watchlistService)
+	userListHandler := handler.NewUserListHandler(userListService)
+	watchHistoryHandler := handler.NewWatchHistoryHandler(watchHistoryService)
+	moderationHandler := handler.NewModerationHandler(moderationService)
+	analyticsHandler := handler.NewAnalyticsHandler(analyticsService)
 
 	// 6. Setup router
 	router := mux.NewRouter()
@@ -69,10 +110,14 @@ func main() {
 	router.HandleFunc("/api/titles/search", titleHandler.SearchTitles).Methods("GET", "OPTIONS")
 	router.HandleFunc("/api/titles/filter-options", titleHandler.GetFilterOptions).Methods("GET", "OPTIONS")
 	router.HandleFunc("/api/titles/filter", titleHandler.FilterTitles).Methods("POST", "OPTIONS")
-	router.HandleFunc("/api/titles/{id}/detail", titleHandler.GetTitleDetail).Methods("GET", "OPTIONS")
-	
-	// Reviews public routes
-	router.HandleFunc("/api/reviews/{title}", reviewHandler.GetReviewsByTitle).Methods("GET", "OPTIONS")
+	// Detail pakai OptionalAuth supaya detail view tercatat dengan user_id kalau login
+	router.Handle("/api/titles/{id}/detail", middleware.OptionalAuth(authService)(http.HandlerFunc(titleHandler.GetTitleDetail))).Methods("GET", "OPTIONS")
+	router.HandleFunc("/api/titles/{id}/alternate-titles", titleHandler.GetAlternateTitles).Methods("GET", "OPTIONS")
+	router.HandleFunc("/api/titles/{id}/seasons", titleHandler.GetSeasons).Methods("GET", "OPTIONS")
+	router.HandleFunc("/api/titles/{id}/seasons/{n}/episodes", titleHandler.GetSeasonEpisodes).Methods("GET", "OPTIONS")
+	router.HandleFunc("/api/persons/{id}", personHandler.GetPersonDetail).Methods("GET", "OPTIONS")
+	router.HandleFunc("/api/persons/{id}/filmography", personHandler.GetFilmography).Methods("GET", "OPTIONS")
+	router.HandleFunc("/api/autocomplete", autocompleteHandler.Autocomplete).Methods("GET", "OPTIONS")
 
 	// 10. Protected routes (butuh JWT token)
 	// Wrap handler dengan Auth middleware - HANYA untuk /api/auth/* paths
@@ -101,6 +146,110 @@ func main() {
 	// Delete review
 	protectedReviewRouter.HandleFunc("/{id}", reviewHandler.DeleteReview).Methods("DELETE", "OPTIONS")
 
+	// Helpfulness vote (tidak bisa vote review sendiri)
+	protectedReviewRouter.HandleFunc("/{id:[0-9]+}/vote", reviewHandler.VoteReview).Methods("PUT", "OPTIONS")
+	protectedReviewRouter.HandleFunc("/{id:[0-9]+}/vote", reviewHandler.RemoveReviewVote).Methods("DELETE", "OPTIONS")
+
+	// Comments & replies (edit/soft-delete hanya owner)
+	protectedReviewRouter.HandleFunc("/{id:[0-9]+}/comments", reviewHandler.CreateComment).Methods("POST", "OPTIONS")
+	protectedReviewRouter.HandleFunc("/comments/{id:[0-9]+}", reviewHandler.UpdateComment).Methods("PUT", "OPTIONS")
+	protectedReviewRouter.HandleFunc("/comments/{id:[0-9]+}", reviewHandler.DeleteComment).Methods("DELETE", "OPTIONS")
+
+	// Report review ke moderator
+	protectedReviewRouter.HandleFunc("/{id:[0-9]+}/report", moderationHandler.ReportReview).Methods("POST", "OPTIONS")
+
+	// Edit history (author atau moderator)
+	protectedReviewRouter.HandleFunc("/{id:[0-9]+}/history", reviewHandler.GetReviewHistory).Methods("GET", "OPTIONS")
+
+	// Reviews public routes (didaftarkan setelah protected routes supaya /user dan /check tidak tertangkap {title})
+	// OptionalAuth: my_vote terisi kalau user login
+	publicReviewRouter := router.PathPrefix("/api/reviews").Subrouter()
+	publicReviewRouter.Use(middleware.OptionalAuth(authService))
+	publicReviewRouter.HandleFunc("/{title}", reviewHandler.GetReviewsByTitle).Methods("GET", "OPTIONS")
+	publicReviewRouter.HandleFunc("/{id:[0-9]+}/comments", reviewHandler.GetComments).Methods("GET", "OPTIONS")
+
+	// 12. Protected watchlist routes (butuh JWT token)
+	protectedWatchlistRouter := router.PathPrefix("/api/watchlist").Subrouter()
+	protectedWatchlistRouter.Use(middleware.Auth(authService))
+
+	// Paged watchlist & add title
+	protectedWatchlistRouter.HandleFunc("", watchlistHandler.GetWatchlist).Methods("GET", "OPTIONS")
+	protectedWatchlistRouter.HandleFunc("", watchlistHandler.AddToWatchlist).Methods("POST", "OPTIONS")
+
+	// Check apakah title ada di watchlist
+	protectedWatchlistRouter.HandleFunc("/status/{title}", watchlistHandler.GetWatchlistStatus).Methods("GET", "OPTIONS")
+
+	// Remove title
+	protectedWatchlistRouter.HandleFunc("/{title}", watchlistHandler.RemoveFromWatchlist).Methods("DELETE", "OPTIONS")
+
+	// 13. Protected user lists routes (butuh JWT token, hanya owner yang bisa ubah list)
+	protectedListRouter := router.PathPrefix("/api/lists").Subrouter()
+	protectedListRouter.Use(middleware.Auth(authService))
+
+	// List milik user & create list
+	protectedListRouter.HandleFunc("", userListHandler.GetMyLists).Methods("GET", "OPTIONS")
+	protectedListRouter.HandleFunc("", userListHandler.CreateList).Methods("POST", "OPTIONS")
+
+	// Update/delete list
+	protectedListRouter.HandleFunc("/{id:[0-9]+}", userListHandler.UpdateList).Methods("PUT", "OPTIONS")
+	protectedListRouter.HandleFunc("/{id:[0-9]+}", userListHandler.DeleteList).Methods("DELETE", "OPTIONS")
+
+	// Entries (add, update note, remove, reorder)
+	protectedListRouter.HandleFunc("/{id:[0-9]+}/entries", userListHandler.AddEntry).Methods("POST", "OPTIONS")
+	protectedListRouter.HandleFunc("/{id:[0-9]+}/entries/{title}", userListHandler.UpdateEntry).Methods("PUT", "OPTIONS")
+	protectedListRouter.HandleFunc("/{id:[0-9]+}/entries/{title}", userListHandler.RemoveEntry).Methods("DELETE", "OPTIONS")
+	protectedListRouter.HandleFunc("/{id:[0-9]+}/order", userListHandler.ReorderEntries).Methods("PUT", "OPTIONS")
+
+	// Public list by slug (OptionalAuth: owner tetap bisa baca private list-nya sendiri)
+	publicListRouter := router.PathPrefix("/api/lists").Subrouter()
+	publicListRouter.Use(middleware.OptionalAuth(authService))
+	publicListRouter.HandleFunc("/{slug}", userListHandler.GetListBySlug).Methods("GET", "OPTIONS")
+
+	// 14. Protected watch history routes (butuh JWT token)
+	protectedHistoryRouter := router.PathPrefix("/api/history").Subrouter()
+	protectedHistoryRouter.Use(middleware.Auth(authService))
+
+	// Diary (paged, filter from/to) & log tontonan
+	protectedHistoryRouter.HandleFunc("", watchHistoryHandler.GetHistory).Methods("GET", "OPTIONS")
+	protectedHistoryRouter.HandleFunc("", watchHistoryHandler.LogWatch).Methods("POST", "OPTIONS")
+
+	// Year-in-review stats (tanpa year = tahun berjalan)
+	protectedHistoryRouter.HandleFunc("/stats", watchHistoryHandler.GetYearInReview).Methods("GET", "OPTIONS")
+	protectedHistoryRouter.HandleFunc("/stats/{year:[0-9]+}", watchHistoryHandler.GetYearInReview).Methods("GET", "OPTIONS")
+
+	// Episode progress untuk series
+	protectedHistoryRouter.HandleFunc("/series/{id}/progress", watchHistoryHandler.GetSeriesProgress).Methods("GET", "OPTIONS")
+
+	// Delete entry
+	protectedHistoryRouter.HandleFunc("/{id:[0-9]+}", watchHistoryHandler.DeleteWatch).Methods("DELETE", "OPTIONS")
+
+	// 15. Moderation routes (butuh JWT token + role moderator, lihat MODERATOR_ROLES)
+	moderationRouter := router.PathPrefix("/api/admin/moderation").Subrouter()
+	moderationRouter.Use(middleware.Auth(authService))
+	moderationRouter.Use(middleware.RequireRole(cfg.Moderation.Roles...))
+
+	// Moderation queue
+	moderationRouter.HandleFunc("/reports", moderationHandler.GetReports).Methods("GET", "OPTIONS")
+	moderationRouter.HandleFunc("/reports/{id:[0-9]+}/dismiss", moderationHandler.DismissReport).Methods("POST", "OPTIONS")
+
+	// Review actions (hide, restore, delete)
+	moderationRouter.HandleFunc("/reviews/{id:[0-9]+}/hide", moderationHandler.HideReview).Methods("POST", "OPTIONS")
+	moderationRouter.HandleFunc("/reviews/{id:[0-9]+}/restore", moderationHandler.RestoreReview).Methods("POST", "OPTIONS")
+	moderationRouter.HandleFunc("/reviews/{id:[0-9]+}", moderationHandler.DeleteReview).Methods("DELETE", "OPTIONS")
+
+	// Author actions (warn, suspend via Users.is_active, reinstate)
+	moderationRouter.HandleFunc("/users/{id:[0-9]+}/warn", moderationHandler.WarnUser).Methods("POST", "OPTIONS")
+	moderationRouter.HandleFunc("/users/{id:[0-9]+}/suspend", moderationHandler.SuspendUser).Methods("POST", "OPTIONS")
+	moderationRouter.HandleFunc("/users/{id:[0-9]+}/reinstate", moderationHandler.ReinstateUser).Methods("POST", "OPTIONS")
+
+	// 16. Analytics routes (butuh JWT token + role executive, lihat ANALYTICS_ROLES)
+	analyticsRouter := router.PathPrefix("/api/analytics").Subrouter()
+	analyticsRouter.Use(middleware.Auth(authService))
+	analyticsRouter.Use(middleware.RequireRole(cfg.Analytics.Roles...))
+
+	// Daily views, unique viewers & review conversion per title (?days=, default 30)
+	analyticsRouter.HandleFunc("/titles/{id}", analyticsHandler.GetTitleAnalytics).Methods("GET", "OPTIONS")
+
 	// Health check endpoint (untuk monitoring)
 	router.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -116,7 +265,7 @@ func main() {
 	fmt.Println("   POST   http://" + addr + "/api/auth/login")
 	fmt.Println("   GET    http://" + addr + "/api/auth/profile (protected)")
 	fmt.Println("   GET    http://" + addr + "/health")
-	fmt.Println("\n Ready to accept requests!\n")
+	fmt.Print("\n Ready to accept requests!\n\n")
 
 	// Start HTTP server
 	if err := http.ListenAndServe(addr, router); err != nil {
@@ -0,0 +1,77 @@
+// Command review-orphans adalah maintenance command untuk mencari review yang target title-nya tidak valid:
+// title_id tidak ada di table titles, atau review untuk episode padahal REVIEW_ALLOW_EPISODES tidak aktif.
+// Command ini hanya melaporkan (read-only), tidak menghapus review.
+//
+// Usage:
+//
+//	go run ./cmd/review-orphans [-json]
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"film-dashboard-api/internal/config"
+	"film-dashboard-api/internal/database"
+	"film-dashboard-api/internal/models"
+	"film-dashboard-api/internal/repository"
+)
+
+func main() {
+	asJSON := flag.Bool("json", false, "output report sebagai JSON")
+	flag.Parse()
+
+	// 1. Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("❌ Failed to load config: %v", err)
+	}
+
+	// 2. Connect to database
+	db, err := database.Connect(cfg.GetConnectionString())
+	if err != nil {
+		log.Fatalf("❌ Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// 3. Cari orphan reviews (review episode hanya dilaporkan kalau episode tidak diizinkan)
+	reviewRepo := repository.NewReviewRepository(db)
+	orphans, err := reviewRepo.FindOrphanReviews(!cfg.Review.AllowEpisodes)
+	if err != nil {
+		log.Fatalf("❌ Failed to find orphan reviews: %v", err)
+	}
+
+	// 4. Print report
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(orphans); err != nil {
+			log.Fatalf("❌ Failed to encode report: %v", err)
+		}
+		return
+	}
+
+	if len(orphans) == 0 {
+		fmt.Println("✅ No orphan reviews found")
+		return
+	}
+
+	counts := make(map[string]int)
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "REVIEW_ID\tUSER\tTITLE_ID\tRATING\tCREATED_AT\tREASON")
+	for _, orphan := range orphans {
+		counts[orphan.Reason]++
+		fmt.Fprintf(writer, "%d\t%s (%d)\t%s\t%d\t%s\t%s\n",
+			orphan.ReviewID, orphan.Username, orphan.UserID, orphan.TitleID,
+			orphan.Rating, orphan.CreatedAt.Format("2006-01-02"), orphan.Reason)
+	}
+	writer.Flush()
+
+	fmt.Printf("\n⚠️  Found %d orphan reviews (%s: %d, %s: %d)\n", len(orphans),
+		models.OrphanReasonMissingTitle, counts[models.OrphanReasonMissingTitle],
+		models.OrphanReasonEpisode, counts[models.OrphanReasonEpisode])
+}
@@ -4,23 +4,31 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 // Config menyimpan semua konfigurasi aplikasi
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	CORS     CORSConfig
+	Server       ServerConfig
+	Database     DatabaseConfig
+	JWT          JWTConfig
+	CORS         CORSConfig
+	Autocomplete AutocompleteConfig
+	Moderation   ModerationConfig
+	Review       ReviewConfig
+	Community    CommunityScoreConfig
+	Trending     TrendingConfig
+	Analytics    AnalyticsConfig
 }
 
 // ServerConfig untuk konfigurasi server
 type ServerConfig struct {
-	Port        string
-	Host        string
-	Environment string
+	Port           string
+	Host           string
+	Environment    string
+	TrustedProxies []string // IP / CIDR proxy yang boleh mengisi X-Forwarded-For (kosong = diabaikan)
 }
 
 // DatabaseConfig untuk konfigurasi database SQL Server
@@ -44,6 +52,43 @@ type CORSConfig struct {
 	AllowedOrigins string
 }
 
+// AutocompleteConfig untuk konfigurasi in-memory autocomplete index
+type AutocompleteConfig struct {
+	RefreshMinutes int // interval refresh index dari database
+}
+
+// ModerationConfig untuk konfigurasi review moderation
+type ModerationConfig struct {
+	Roles []string // role_name yang boleh akses /api/admin/moderation
+}
+
+// ReviewConfig untuk konfigurasi validasi & sanitisasi review text
+type ReviewConfig struct {
+	MaxLength     int      // panjang maksimal review_text (karakter)
+	BlockedWords  []string // kata yang tidak boleh muncul di review / comment
+	MaskBlocked   bool     // true = kata diganti "***", false = review ditolak dengan validation error
+	AllowEpisodes bool     // true = user boleh review episode (default hanya movie / series)
+}
+
+// CommunityScoreConfig untuk konfigurasi Bayesian community score
+// score = (vote_average*vote_count + SUM(review rating) + PriorMean*PriorWeight) / (vote_count + review_count + PriorWeight)
+type CommunityScoreConfig struct {
+	PriorWeight int      // jumlah "virtual votes" ke arah PriorMean (semakin besar, title dengan sedikit vote makin ditarik ke rata-rata)
+	PriorMean   *float64 // nil = dihitung dari rata-rata vote catalog saat startup
+}
+
+// TrendingConfig untuk konfigurasi trending berbasis aktivitas user
+type TrendingConfig struct {
+	RefreshMinutes int // interval recompute TrendingScores di background
+}
+
+// AnalyticsConfig untuk konfigurasi detail-view tracking & per-title analytics
+type AnalyticsConfig struct {
+	Roles             []string // role_name yang boleh akses /api/analytics
+	ViewFlushSeconds  int      // interval flush buffer view events ke database
+	ViewBufferMaxSize int      // maksimal view events di memory (event baru di-drop kalau penuh)
+}
+
 // Load membaca environment variables dan return Config
 func Load() (*Config, error) {
 	// Load .env file (kalau ada)
@@ -67,11 +112,56 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("invalid JWT_REFRESH_EXPIRATION_DAYS: %v", err)
 	}
 
+	autocompleteRefresh, err := strconv.Atoi(getEnv("AUTOCOMPLETE_REFRESH_MINUTES", "30"))
+	if err != nil || autocompleteRefresh <= 0 {
+		return nil, fmt.Errorf("invalid AUTOCOMPLETE_REFRESH_MINUTES: %q", os.Getenv("AUTOCOMPLETE_REFRESH_MINUTES"))
+	}
+
+	trendingRefresh, err := strconv.Atoi(getEnv("TRENDING_REFRESH_MINUTES", "10"))
+	if err != nil || trendingRefresh <= 0 {
+		return nil, fmt.Errorf("invalid TRENDING_REFRESH_MINUTES: %q", os.Getenv("TRENDING_REFRESH_MINUTES"))
+	}
+
+	viewFlushSeconds, err := strconv.Atoi(getEnv("VIEW_FLUSH_SECONDS", "5"))
+	if err != nil || viewFlushSeconds <= 0 {
+		return nil, fmt.Errorf("invalid VIEW_FLUSH_SECONDS: %q", os.Getenv("VIEW_FLUSH_SECONDS"))
+	}
+
+	viewBufferMaxSize, err := strconv.Atoi(getEnv("VIEW_BUFFER_MAX_SIZE", "10000"))
+	if err != nil || viewBufferMaxSize <= 0 {
+		return nil, fmt.Errorf("invalid VIEW_BUFFER_MAX_SIZE: %q", os.Getenv("VIEW_BUFFER_MAX_SIZE"))
+	}
+
+	reviewMaxLength, err := strconv.Atoi(getEnv("REVIEW_MAX_LENGTH", "5000"))
+	if err != nil || reviewMaxLength <= 0 {
+		return nil, fmt.Errorf("invalid REVIEW_MAX_LENGTH: %q", os.Getenv("REVIEW_MAX_LENGTH"))
+	}
+
+	blockedWordsAction := strings.ToLower(getEnv("REVIEW_BLOCKED_WORDS_ACTION", "reject"))
+	if blockedWordsAction != "reject" && blockedWordsAction != "mask" {
+		return nil, fmt.Errorf("invalid REVIEW_BLOCKED_WORDS_ACTION: %q (valid: reject, mask)", blockedWordsAction)
+	}
+
+	communityPriorWeight, err := strconv.Atoi(getEnv("COMMUNITY_SCORE_PRIOR_WEIGHT", "50"))
+	if err != nil || communityPriorWeight < 0 {
+		return nil, fmt.Errorf("invalid COMMUNITY_SCORE_PRIOR_WEIGHT: %q", os.Getenv("COMMUNITY_SCORE_PRIOR_WEIGHT"))
+	}
+
+	var communityPriorMean *float64
+	if raw := getEnv("COMMUNITY_SCORE_PRIOR_MEAN", ""); raw != "" {
+		mean, err := strconv.ParseFloat(raw, 64)
+		if err != nil || mean < 0 || mean > 10 {
+			return nil, fmt.Errorf("invalid COMMUNITY_SCORE_PRIOR_MEAN: %q (must be 0-10)", raw)
+		}
+		communityPriorMean = &mean
+	}
+
 	config := &Config{
 		Server: ServerConfig{
-			Port:        getEnv("SERVER_PORT", "8080"),
-			Host:        getEnv("SERVER_HOST", "localhost"),
-			Environment: getEnv("ENVIRONMENT", "development"),
+			Port:           getEnv("SERVER_PORT", "8080"),
+			Host:           getEnv("SERVER_HOST", "localhost"),
+			Environment:    getEnv("ENVIRONMENT", "development"),
+			TrustedProxies: splitList(getEnv("TRUSTED_PROXIES", "")),
 		},
 		Database: DatabaseConfig{
 			Server:   getEnv("DB_SERVER", ""),
@@ -88,6 +178,30 @@ func Load() (*Config, error) {
 		CORS: CORSConfig{
 			AllowedOrigins: getEnv("ALLOWED_ORIGINS", "http://localhost:3000"),
 		},
+		Autocomplete: AutocompleteConfig{
+			RefreshMinutes: autocompleteRefresh,
+		},
+		Moderation: ModerationConfig{
+			Roles: splitList(getEnv("MODERATOR_ROLES", "admin,moderator")),
+		},
+		Review: ReviewConfig{
+			MaxLength:     reviewMaxLength,
+			BlockedWords:  splitList(getEnv("REVIEW_BLOCKED_WORDS", "")),
+			MaskBlocked:   blockedWordsAction == "mask",
+			AllowEpisodes: getEnv("REVIEW_ALLOW_EPISODES", "false") == "true",
+		},
+		Community: CommunityScoreConfig{
+			PriorWeight: communityPriorWeight,
+			PriorMean:   communityPriorMean,
+		},
+		Trending: TrendingConfig{
+			RefreshMinutes: trendingRefresh,
+		},
+		Analytics: AnalyticsConfig{
+			Roles:             splitList(getEnv("ANALYTICS_ROLES", "executive,admin")),
+			ViewFlushSeconds:  viewFlushSeconds,
+			ViewBufferMaxSize: viewBufferMaxSize,
+		},
 	}
 
 	// Validasi konfigurasi penting
@@ -133,3 +247,14 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+// splitList memecah value comma-separated menjadi slice (item kosong diabaikan)
+func splitList(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
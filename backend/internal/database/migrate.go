@@ -0,0 +1,104 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// migrationFiles berisi semua file migrations/*.sql (di-embed ke binary)
+// Nama file: NNNN_deskripsi.sql, dijalankan berurutan berdasarkan nama
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// batchSeparator memecah script di baris "GO" (sama seperti di SSMS / sqlcmd)
+var batchSeparator = regexp.MustCompile(`(?im)^\s*GO\s*$`)
+
+// Migrate menjalankan semua migration yang belum pernah dijalankan
+// Migration yang sudah jalan dicatat di table schema_migrations (version = nama file)
+// Setiap migration dijalankan dalam satu transaction: kalau gagal, tidak ada perubahan yang tersisa
+func Migrate(db *sql.DB) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	// 1. Pastikan table schema_migrations ada
+	_, err := db.ExecContext(ctx, `IF OBJECT_ID(N'dbo.schema_migrations', N'U') IS NULL
+	CREATE TABLE dbo.schema_migrations (
+		version NVARCHAR(255) NOT NULL PRIMARY KEY,
+		applied_at DATETIME NOT NULL DEFAULT GETDATE()
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	// 2. Ambil migration yang sudah pernah dijalankan
+	applied := make(map[string]bool)
+	rows, err := db.QueryContext(ctx, `SELECT version FROM dbo.schema_migrations`)
+	if err != nil {
+		return fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan migration version: %w", err)
+		}
+		applied[version] = true
+	}
+	rows.Close()
+
+	// 3. Jalankan migration baru secara berurutan
+	names, err := migrationFiles.ReadDir("migrations")
+	if err != nil {
+		return fmt.Errorf("failed to read migrations: %w", err)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i].Name() < names[j].Name() })
+
+	for _, entry := range names {
+		version := entry.Name()
+		if applied[version] {
+			continue
+		}
+
+		script, err := migrationFiles.ReadFile("migrations/" + version)
+		if err != nil {
+			return fmt.Errorf("failed to read migration %s: %w", version, err)
+		}
+		if err := applyMigration(ctx, db, version, string(script)); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Applied migration %s\n", version)
+	}
+
+	return nil
+}
+
+// applyMigration menjalankan satu migration script (per batch "GO") dan mencatatnya
+func applyMigration(ctx context.Context, db *sql.DB, version string, script string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin migration %s: %w", version, err)
+	}
+	defer tx.Rollback()
+
+	for _, batch := range batchSeparator.Split(script, -1) {
+		if strings.TrimSpace(batch) == "" {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, batch); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", version, err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO dbo.schema_migrations (version) VALUES (@p1)`, version); err != nil {
+		return fmt.Errorf("failed to record migration %s: %w", version, err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,23 @@
+-- Watchlist: title yang disimpan user untuk ditonton nanti
+CREATE TABLE Watchlist (
+    watchlist_id INT PRIMARY KEY IDENTITY(1,1),
+
+    user_id INT NOT NULL,
+    title_id NVARCHAR(20) NOT NULL,
+
+    added_at DATETIME NOT NULL DEFAULT GETDATE(),
+
+    -- Relasi ke Users
+    CONSTRAINT FK_Watchlist_Users FOREIGN KEY (user_id)
+        REFERENCES Users(user_id),
+
+    -- Relasi ke Titles
+    CONSTRAINT FK_Watchlist_Titles FOREIGN KEY (title_id)
+        REFERENCES titles(title_id),
+
+    -- 1 title hanya boleh sekali di watchlist user
+    CONSTRAINT UQ_Watchlist UNIQUE(user_id, title_id)
+);
+GO
+
+CREATE INDEX IX_Watchlist_UserId_AddedAt ON Watchlist(user_id, added_at DESC);
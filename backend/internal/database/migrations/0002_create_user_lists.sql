@@ -0,0 +1,49 @@
+-- User lists: koleksi title buatan user (contoh: "Best 90s noir")
+-- visibility: public (bisa dibaca semua orang), unlisted (hanya yang tahu slug), private (hanya owner)
+CREATE TABLE UserLists (
+    list_id INT PRIMARY KEY IDENTITY(1,1),
+
+    user_id INT NOT NULL,
+
+    name NVARCHAR(100) NOT NULL,
+    description NVARCHAR(1000) NULL,
+    slug NVARCHAR(100) NOT NULL,
+    visibility NVARCHAR(10) NOT NULL DEFAULT 'private'
+        CHECK (visibility IN ('public', 'unlisted', 'private')),
+
+    created_at DATETIME NOT NULL DEFAULT GETDATE(),
+    updated_at DATETIME NOT NULL DEFAULT GETDATE(),
+
+    -- Relasi ke Users
+    CONSTRAINT FK_UserLists_Users FOREIGN KEY (user_id)
+        REFERENCES Users(user_id),
+
+    CONSTRAINT UQ_UserLists_Slug UNIQUE(slug)
+);
+GO
+
+CREATE INDEX IX_UserLists_UserId ON UserLists(user_id);
+GO
+
+-- Entries: urutan ditentukan oleh position (1..n), note optional per entry
+CREATE TABLE UserListEntries (
+    list_id INT NOT NULL,
+    title_id NVARCHAR(20) NOT NULL,
+
+    position INT NOT NULL,
+    note NVARCHAR(1000) NULL,
+
+    added_at DATETIME NOT NULL DEFAULT GETDATE(),
+
+    CONSTRAINT PK_UserListEntries PRIMARY KEY (list_id, title_id),
+
+    -- Entries ikut terhapus kalau list dihapus
+    CONSTRAINT FK_UserListEntries_UserLists FOREIGN KEY (list_id)
+        REFERENCES UserLists(list_id) ON DELETE CASCADE,
+
+    CONSTRAINT FK_UserListEntries_Titles FOREIGN KEY (title_id)
+        REFERENCES titles(title_id)
+);
+GO
+
+CREATE INDEX IX_UserListEntries_Position ON UserListEntries(list_id, position);
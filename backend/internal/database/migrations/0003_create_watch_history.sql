@@ -0,0 +1,33 @@
+-- Watch history / viewing diary: satu row per kali user menonton sebuah title
+-- title_id bisa movie, series, atau episode (episode progress dihitung via table episodes)
+CREATE TABLE WatchHistory (
+    watch_id INT PRIMARY KEY IDENTITY(1,1),
+
+    user_id INT NOT NULL,
+    title_id NVARCHAR(20) NOT NULL,
+
+    watched_on DATE NOT NULL,
+    is_rewatch BIT NOT NULL DEFAULT 0,
+
+    -- Optional: review yang ditulis untuk tontonan ini
+    review_id INT NULL,
+    note NVARCHAR(500) NULL,
+
+    created_at DATETIME NOT NULL DEFAULT GETDATE(),
+
+    -- Relasi ke Users
+    CONSTRAINT FK_WatchHistory_Users FOREIGN KEY (user_id)
+        REFERENCES Users(user_id),
+
+    -- Relasi ke Titles
+    CONSTRAINT FK_WatchHistory_Titles FOREIGN KEY (title_id)
+        REFERENCES titles(title_id),
+
+    -- Relasi ke Reviews (link dilepas kalau review dihapus)
+    CONSTRAINT FK_WatchHistory_Reviews FOREIGN KEY (review_id)
+        REFERENCES Reviews(review_id) ON DELETE SET NULL
+);
+GO
+
+CREATE INDEX IX_WatchHistory_UserId_WatchedOn ON WatchHistory(user_id, watched_on DESC);
+CREATE INDEX IX_WatchHistory_UserId_TitleId ON WatchHistory(user_id, title_id);
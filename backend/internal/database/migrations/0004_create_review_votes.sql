@@ -0,0 +1,25 @@
+-- Review votes: user menandai review user lain sebagai helpful / not helpful
+-- 1 vote per user per review (vote bisa diganti), user tidak bisa vote review sendiri (dicek di service)
+CREATE TABLE ReviewVotes (
+    review_id INT NOT NULL,
+    user_id INT NOT NULL,
+
+    -- 1 = helpful, 0 = not helpful
+    is_helpful BIT NOT NULL,
+
+    created_at DATETIME NOT NULL DEFAULT GETDATE(),
+    updated_at DATETIME NOT NULL DEFAULT GETDATE(),
+
+    CONSTRAINT PK_ReviewVotes PRIMARY KEY (review_id, user_id),
+
+    -- Relasi ke Reviews (vote ikut terhapus kalau review dihapus)
+    CONSTRAINT FK_ReviewVotes_Reviews FOREIGN KEY (review_id)
+        REFERENCES Reviews(review_id) ON DELETE CASCADE,
+
+    -- Relasi ke Users
+    CONSTRAINT FK_ReviewVotes_Users FOREIGN KEY (user_id)
+        REFERENCES Users(user_id)
+);
+GO
+
+CREATE INDEX IX_ReviewVotes_UserId ON ReviewVotes(user_id);
@@ -0,0 +1,37 @@
+-- Review comments: diskusi di bawah sebuah review, reply ke comment lain lewat parent_comment_id
+-- Delete dari owner bersifat soft-delete (is_deleted = 1) supaya thread reply tetap utuh
+CREATE TABLE ReviewComments (
+    comment_id INT PRIMARY KEY IDENTITY(1,1),
+
+    review_id INT NOT NULL,
+    user_id INT NOT NULL,
+
+    -- NULL = top-level comment, selain itu reply ke comment lain di review yang sama
+    parent_comment_id INT NULL,
+
+    body NVARCHAR(2000) NOT NULL,
+
+    is_deleted BIT NOT NULL DEFAULT 0,
+
+    created_at DATETIME NOT NULL DEFAULT GETDATE(),
+    updated_at DATETIME NOT NULL DEFAULT GETDATE(),
+    deleted_at DATETIME NULL,
+
+    -- Relasi ke Reviews (comments ikut terhapus kalau review dihapus)
+    CONSTRAINT FK_ReviewComments_Reviews FOREIGN KEY (review_id)
+        REFERENCES Reviews(review_id) ON DELETE CASCADE,
+
+    -- Relasi ke Users
+    CONSTRAINT FK_ReviewComments_Users FOREIGN KEY (user_id)
+        REFERENCES Users(user_id),
+
+    -- Relasi ke parent comment
+    CONSTRAINT FK_ReviewComments_Parent FOREIGN KEY (parent_comment_id)
+        REFERENCES ReviewComments(comment_id)
+);
+GO
+
+CREATE INDEX IX_ReviewComments_ReviewId_Parent ON ReviewComments(review_id, parent_comment_id, created_at);
+GO
+
+CREATE INDEX IX_ReviewComments_Parent ON ReviewComments(parent_comment_id);
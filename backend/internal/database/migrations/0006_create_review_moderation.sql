@@ -0,0 +1,68 @@
+-- Review moderation: review bisa di-hide oleh moderator (hilang dari public listings,
+-- tetap terlihat oleh author di /api/reviews/user)
+ALTER TABLE Reviews ADD
+    is_hidden BIT NOT NULL CONSTRAINT DF_Reviews_IsHidden DEFAULT 0,
+    hidden_at DATETIME NULL;
+GO
+
+-- Review reports: laporan user terhadap review (1 laporan per user per review)
+-- status: open (belum ditangani), resolved (review di-hide / dihapus), dismissed (laporan ditolak)
+CREATE TABLE ReviewReports (
+    report_id INT PRIMARY KEY IDENTITY(1,1),
+
+    review_id INT NOT NULL,
+    reporter_id INT NOT NULL,
+
+    reason NVARCHAR(20) NOT NULL
+        CHECK (reason IN ('spam', 'offensive', 'harassment', 'spoiler', 'other')),
+    details NVARCHAR(1000) NULL,
+
+    status NVARCHAR(10) NOT NULL DEFAULT 'open'
+        CHECK (status IN ('open', 'resolved', 'dismissed')),
+
+    created_at DATETIME NOT NULL DEFAULT GETDATE(),
+    resolved_at DATETIME NULL,
+    resolved_by INT NULL,
+
+    -- Relasi ke Reviews (report ikut terhapus kalau review dihapus, jejaknya ada di ModerationActions)
+    CONSTRAINT FK_ReviewReports_Reviews FOREIGN KEY (review_id)
+        REFERENCES Reviews(review_id) ON DELETE CASCADE,
+
+    -- Relasi ke Users (reporter & moderator)
+    CONSTRAINT FK_ReviewReports_Reporter FOREIGN KEY (reporter_id)
+        REFERENCES Users(user_id),
+    CONSTRAINT FK_ReviewReports_ResolvedBy FOREIGN KEY (resolved_by)
+        REFERENCES Users(user_id),
+
+    CONSTRAINT UQ_ReviewReports UNIQUE(review_id, reporter_id)
+);
+GO
+
+CREATE INDEX IX_ReviewReports_Status_CreatedAt ON ReviewReports(status, created_at);
+GO
+
+-- Moderation actions: audit trail semua tindakan moderator
+-- review_id sengaja tanpa FK supaya jejak tetap ada setelah review dihapus
+CREATE TABLE ModerationActions (
+    action_id INT PRIMARY KEY IDENTITY(1,1),
+
+    moderator_id INT NOT NULL,
+    action NVARCHAR(20) NOT NULL
+        CHECK (action IN ('hide_review', 'restore_review', 'delete_review', 'dismiss_report',
+                          'warn_user', 'suspend_user', 'reinstate_user')),
+
+    review_id INT NULL,
+    report_id INT NULL,
+    target_user_id INT NULL,
+    reason NVARCHAR(500) NULL,
+
+    created_at DATETIME NOT NULL DEFAULT GETDATE(),
+
+    CONSTRAINT FK_ModerationActions_Moderator FOREIGN KEY (moderator_id)
+        REFERENCES Users(user_id),
+    CONSTRAINT FK_ModerationActions_TargetUser FOREIGN KEY (target_user_id)
+        REFERENCES Users(user_id)
+);
+GO
+
+CREATE INDEX IX_ModerationActions_TargetUser ON ModerationActions(target_user_id, created_at DESC);
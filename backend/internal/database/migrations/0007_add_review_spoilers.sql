@@ -0,0 +1,3 @@
+-- Spoiler flag: review yang berisi spoiler di-redact di listings kecuali client minta ?spoilers=true
+ALTER TABLE Reviews ADD
+    contains_spoilers BIT NOT NULL CONSTRAINT DF_Reviews_ContainsSpoilers DEFAULT 0;
@@ -0,0 +1,27 @@
+-- Review edit history: setiap kali review di-update (rating / review_text / spoiler flag berubah),
+-- versi sebelumnya disimpan di ReviewHistory dan Reviews.edited_at di-set untuk marker "edited"
+ALTER TABLE Reviews ADD
+    edited_at DATETIME NULL;
+GO
+
+CREATE TABLE ReviewHistory (
+    history_id INT PRIMARY KEY IDENTITY(1,1),
+
+    review_id INT NOT NULL,
+
+    -- Isi review sebelum di-edit
+    rating INT NOT NULL,
+    review_text NVARCHAR(MAX) NOT NULL,
+    contains_spoilers BIT NOT NULL,
+
+    -- written_at = kapan versi ini ditulis, replaced_at = kapan versi ini diganti edit berikutnya
+    written_at DATETIME NOT NULL,
+    replaced_at DATETIME NOT NULL DEFAULT GETDATE(),
+
+    -- Relasi ke Reviews (history ikut terhapus kalau review dihapus)
+    CONSTRAINT FK_ReviewHistory_Reviews FOREIGN KEY (review_id)
+        REFERENCES Reviews(review_id) ON DELETE CASCADE
+);
+GO
+
+CREATE INDEX IX_ReviewHistory_ReviewId ON ReviewHistory(review_id, replaced_at);
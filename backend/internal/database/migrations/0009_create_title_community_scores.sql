@@ -0,0 +1,26 @@
+-- Community score: Bayesian average dari imported votes (titles.vote_average/vote_count)
+-- dan rating review lokal (Reviews.rating, review yang di-hide tidak dihitung)
+-- Di-rebuild saat startup dan di-update per title setiap review dibuat / diubah / dihapus / di-hide
+CREATE TABLE TitleCommunityScores (
+    title_id NVARCHAR(20) NOT NULL PRIMARY KEY,
+
+    -- Agregat review lokal
+    review_count INT NOT NULL DEFAULT 0,
+    review_sum INT NOT NULL DEFAULT 0,
+
+    -- NULL kalau title belum punya vote sama sekali dan prior weight = 0
+    community_score DECIMAL(4,2) NULL,
+
+    updated_at DATETIME NOT NULL DEFAULT GETDATE(),
+
+    CONSTRAINT FK_TitleCommunityScores_Titles FOREIGN KEY (title_id)
+        REFERENCES titles(title_id) ON DELETE CASCADE
+);
+GO
+
+CREATE INDEX IX_TitleCommunityScores_Score ON TitleCommunityScores(community_score DESC, title_id);
+GO
+
+-- Agregasi review per title (dipakai saat refresh community score & rating summary)
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE name = 'IX_Reviews_TitleId' AND object_id = OBJECT_ID('Reviews'))
+    CREATE INDEX IX_Reviews_TitleId ON Reviews(title_id) INCLUDE (rating, is_hidden);
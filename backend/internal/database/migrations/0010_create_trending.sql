@@ -0,0 +1,46 @@
+-- Title views: satu row per request GET /api/titles/{id}/detail (dipakai sebagai signal trending)
+-- Tanpa foreign key supaya insert event tetap murah; title_id sudah divalidasi oleh detail endpoint
+CREATE TABLE TitleViews (
+    view_id BIGINT PRIMARY KEY IDENTITY(1,1),
+
+    title_id NVARCHAR(20) NOT NULL,
+    user_id INT NULL, -- NULL = tanpa login
+
+    viewed_at DATETIME NOT NULL DEFAULT GETDATE()
+);
+GO
+
+CREATE INDEX IX_TitleViews_ViewedAt ON TitleViews(viewed_at) INCLUDE (title_id);
+GO
+
+CREATE INDEX IX_TitleViews_TitleId ON TitleViews(title_id, viewed_at);
+GO
+
+-- Trending scores per time window (day / week / month), dihitung ulang oleh background job
+-- score = SUM(weight signal * 0.5 ^ (umur / half-life)) dari reviews, watchlist adds dan detail views
+CREATE TABLE TrendingScores (
+    time_window NVARCHAR(10) NOT NULL
+        CHECK (time_window IN ('day', 'week', 'month')),
+    title_id NVARCHAR(20) NOT NULL,
+
+    score FLOAT NOT NULL,
+    review_count INT NOT NULL,
+    watchlist_count INT NOT NULL,
+    view_count INT NOT NULL,
+
+    computed_at DATETIME NOT NULL DEFAULT GETDATE(),
+
+    CONSTRAINT PK_TrendingScores PRIMARY KEY (time_window, title_id)
+);
+GO
+
+CREATE INDEX IX_TrendingScores_Score ON TrendingScores(time_window, score DESC);
+GO
+
+-- Signal trending dari Reviews & Watchlist difilter berdasarkan waktu
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE name = 'IX_Reviews_CreatedAt' AND object_id = OBJECT_ID('Reviews'))
+    CREATE INDEX IX_Reviews_CreatedAt ON Reviews(created_at) INCLUDE (title_id, is_hidden);
+GO
+
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE name = 'IX_Watchlist_AddedAt' AND object_id = OBJECT_ID('Watchlist'))
+    CREATE INDEX IX_Watchlist_AddedAt ON Watchlist(added_at) INCLUDE (title_id);
@@ -0,0 +1,15 @@
+-- Visitor key untuk unique viewers di analytics:
+-- "u:<user_id>" untuk user yang login, "a:<hash ip + user agent>" untuk anonymous
+ALTER TABLE TitleViews ADD
+    visitor_key NVARCHAR(64) NULL;
+GO
+
+UPDATE TitleViews
+SET visitor_key = CONCAT('u:', user_id)
+WHERE user_id IS NOT NULL AND visitor_key IS NULL;
+GO
+
+DROP INDEX IX_TitleViews_TitleId ON TitleViews;
+GO
+
+CREATE INDEX IX_TitleViews_TitleId ON TitleViews(title_id, viewed_at) INCLUDE (user_id, visitor_key);
@@ -0,0 +1,22 @@
+-- Index untuk keyset pagination /api/titles/filter & /api/titles/search
+-- Urutan kolom sama dengan titleSorts (expr DIR, title_id ASC) supaya kondisi
+-- "setelah cursor" bisa seek langsung tanpa scan seluruh filtered set
+-- (community sort memakai IX_TitleCommunityScores_Score dari 0009)
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE name = 'IX_titles_Sort_VoteCount' AND object_id = OBJECT_ID('titles'))
+    CREATE INDEX IX_titles_Sort_VoteCount ON titles(vote_count DESC, title_id);
+GO
+
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE name = 'IX_titles_Sort_Popularity' AND object_id = OBJECT_ID('titles'))
+    CREATE INDEX IX_titles_Sort_Popularity ON titles(popularity DESC, title_id);
+GO
+
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE name = 'IX_titles_Sort_VoteAverage' AND object_id = OBJECT_ID('titles'))
+    CREATE INDEX IX_titles_Sort_VoteAverage ON titles(vote_average DESC, title_id);
+GO
+
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE name = 'IX_titles_Sort_StartYear' AND object_id = OBJECT_ID('titles'))
+    CREATE INDEX IX_titles_Sort_StartYear ON titles(startYear DESC, title_id);
+GO
+
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE name = 'IX_titles_Sort_Name' AND object_id = OBJECT_ID('titles'))
+    CREATE INDEX IX_titles_Sort_Name ON titles(name, title_id);
@@ -0,0 +1,3 @@
+-- Reviews.review_text nullable (review lama bisa tanpa teks), jadi ReviewHistory.review_text juga harus nullable
+-- Tanpa ini, edit rating review lama dengan teks NULL gagal saat menyimpan history
+ALTER TABLE ReviewHistory ALTER COLUMN review_text NVARCHAR(MAX) NULL;
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"film-dashboard-api/internal/repository"
+	"film-dashboard-api/internal/service"
+	"film-dashboard-api/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// AnalyticsHandler adalah struct yang berisi semua handler untuk analytics endpoints
+// Di-mount dengan middleware.RequireRole (role di ANALYTICS_ROLES)
+type AnalyticsHandler struct {
+	analyticsService *service.AnalyticsService
+}
+
+// NewAnalyticsHandler adalah constructor untuk bikin instance AnalyticsHandler
+func NewAnalyticsHandler(analyticsService *service.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsService: analyticsService,
+	}
+}
+
+// GetTitleAnalytics adalah handler untuk endpoint GET /api/analytics/titles/{id}?days=30
+// Protected route - hanya role di ANALYTICS_ROLES
+// Return: TitleAnalytics (totals, review conversion, dan breakdown per hari)
+func (h *AnalyticsHandler) GetTitleAnalytics(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get title ID dari URL path
+	titleID := mux.Vars(r)["id"]
+	if titleID == "" {
+		utils.WriteError(w, http.StatusBadRequest, "Title ID is required", nil)
+		return
+	}
+
+	// 3. Parse ?days= (default 30, range divalidasi service)
+	days := service.DefaultAnalyticsDays
+	if raw := r.URL.Query().Get("days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			utils.WriteError(w, http.StatusBadRequest, "Invalid days format", err)
+			return
+		}
+		days = parsed
+	}
+
+	// 4. Call service
+	analytics, err := h.analyticsService.GetTitleAnalytics(titleID, days)
+	if err != nil {
+		switch {
+		case service.IsValidationError(err):
+			utils.WriteError(w, http.StatusBadRequest, err.Error(), err)
+		case errors.Is(err, repository.ErrTitleNotFound):
+			utils.WriteError(w, http.StatusNotFound, err.Error(), err)
+		default:
+			fmt.Printf("❌ Handler Error: %v\n", err)
+			utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch title analytics", err)
+		}
+		return
+	}
+
+	// 5. Return response
+	utils.WriteSuccess(w, "Title analytics retrieved successfully", analytics)
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"film-dashboard-api/internal/service"
+	"film-dashboard-api/internal/utils"
+)
+
+// AutocompleteHandler adalah struct yang berisi handler untuk typeahead search box
+type AutocompleteHandler struct {
+	autocompleteService *service.AutocompleteService
+}
+
+// NewAutocompleteHandler adalah constructor untuk bikin instance AutocompleteHandler
+func NewAutocompleteHandler(autocompleteService *service.AutocompleteService) *AutocompleteHandler {
+	return &AutocompleteHandler{
+		autocompleteService: autocompleteService,
+	}
+}
+
+// Autocomplete adalah handler untuk endpoint GET /api/autocomplete
+// Query param: q (prefix) - required, limit (per kind, default 5, max 20)
+// Return: AutocompleteResponse dengan title dan person suggestions (dari in-memory index)
+func (h *AutocompleteHandler) Autocomplete(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight OPTIONS request
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Check method GET
+	if r.Method != http.MethodGet {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed", nil)
+		return
+	}
+
+	// 3. Get prefix dan limit dari query param
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		utils.WriteError(w, http.StatusBadRequest, "Query (q) is required", nil)
+		return
+	}
+
+	limit := 5
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+		limit = l
+	}
+	if limit > service.AutocompleteMaxLimit {
+		limit = service.AutocompleteMaxLimit
+	}
+
+	// 4. Lookup ke index
+	suggestions, err := h.autocompleteService.Suggest(query, limit)
+	if errors.Is(err, service.ErrAutocompleteNotReady) {
+		utils.WriteError(w, http.StatusServiceUnavailable, "Autocomplete is warming up, please retry shortly", err)
+		return
+	}
+	if err != nil {
+		fmt.Printf("❌ Handler Error: %v\n", err)
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to get suggestions", err)
+		return
+	}
+
+	// 5. Return success response
+	utils.WriteSuccess(w, "Suggestions retrieved successfully", suggestions)
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"film-dashboard-api/internal/models"
+)
+
+// parseFilterQuery membaca FilterRequest dari query string (untuk endpoint GET seperti /api/titles/search)
+// Nama param sama dengan field JSON di FilterRequest (genreIds, yearFrom, ratingMin, ...)
+// List bisa dikirim comma-separated (genreIds=1,2) atau berulang (genreIds=1&genreIds=2)
+// page, limit, dan default sortBy diisi oleh caller
+func parseFilterQuery(query url.Values) (*models.FilterRequest, error) {
+	filter := &models.FilterRequest{
+		Q:                    strings.TrimSpace(query.Get("q")),
+		GenreIDs:             queryList(query, "genreIds"),
+		TypeIDs:              queryList(query, "typeIds"),
+		StatusIDs:            queryList(query, "statusIds"),
+		OriginCountryIDs:     queryList(query, "originCountryIds"),
+		ProductionCountryIDs: queryList(query, "productionCountryIds"),
+		SortBy:               query.Get("sortBy"),
+		Cursor:               query.Get("cursor"),
+	}
+
+	var err error
+	intParams := []struct {
+		name   string
+		target **int
+	}{
+		{"year", &filter.Year},
+		{"yearFrom", &filter.YearFrom},
+		{"yearTo", &filter.YearTo},
+		{"runtimeMin", &filter.RuntimeMin},
+		{"runtimeMax", &filter.RuntimeMax},
+		{"minVotes", &filter.MinVotes},
+	}
+	for _, p := range intParams {
+		if *p.target, err = queryInt(query, p.name); err != nil {
+			return nil, err
+		}
+	}
+	if filter.RatingMin, err = queryFloat(query, "ratingMin"); err != nil {
+		return nil, err
+	}
+	if filter.RatingMax, err = queryFloat(query, "ratingMax"); err != nil {
+		return nil, err
+	}
+	if filter.Adult, err = queryBool(query, "adult"); err != nil {
+		return nil, err
+	}
+	if filter.InProduction, err = queryBool(query, "inProduction"); err != nil {
+		return nil, err
+	}
+	includeFacets, err := queryBool(query, "includeFacets")
+	if err != nil {
+		return nil, err
+	}
+	filter.IncludeFacets = includeFacets != nil && *includeFacets
+
+	return filter, nil
+}
+
+// queryList menggabungkan semua value param (berulang dan/atau comma-separated)
+func queryList(query url.Values, name string) []string {
+	var values []string
+	for _, raw := range query[name] {
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+// queryInt return nil kalau param tidak dikirim, error kalau bukan angka
+func queryInt(query url.Values, name string) (*int, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an integer", name)
+	}
+	return &v, nil
+}
+
+// queryFloat return nil kalau param tidak dikirim, error kalau bukan angka
+func queryFloat(query url.Values, name string) (*float64, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a number", name)
+	}
+	return &v, nil
+}
+
+// queryBool return nil kalau param tidak dikirim, error kalau bukan true/false
+func queryBool(query url.Values, name string) (*bool, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be true or false", name)
+	}
+	return &v, nil
+}
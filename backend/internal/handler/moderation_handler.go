@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"film-dashboard-api/internal/middleware"
+	"film-dashboard-api/internal/models"
+	"film-dashboard-api/internal/repository"
+	"film-dashboard-api/internal/service"
+	"film-dashboard-api/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// ModerationHandler adalah struct yang berisi semua handler untuk review reporting & moderation
+// ReportReview bisa dipakai semua user yang login, endpoint lain hanya untuk moderator
+// (di-mount dengan middleware.RequireRole)
+type ModerationHandler struct {
+	moderationService *service.ModerationService
+}
+
+// NewModerationHandler adalah constructor untuk bikin instance ModerationHandler
+func NewModerationHandler(moderationService *service.ModerationService) *ModerationHandler {
+	return &ModerationHandler{
+		moderationService: moderationService,
+	}
+}
+
+// writeModerationError mapping error dari ModerationService ke HTTP status
+// (validation 400, forbidden 403, not found 404, duplicate report 409, lainnya 500)
+func writeModerationError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case service.IsValidationError(err):
+		utils.WriteError(w, http.StatusBadRequest, err.Error(), err)
+	case errors.Is(err, service.ErrOwnReviewReport),
+		errors.Is(err, service.ErrSelfModeration):
+		utils.WriteError(w, http.StatusForbidden, err.Error(), err)
+	case errors.Is(err, repository.ErrReviewNotFound),
+		errors.Is(err, repository.ErrReportNotFound),
+		errors.Is(err, repository.ErrUserNotFound):
+		utils.WriteError(w, http.StatusNotFound, err.Error(), err)
+	case errors.Is(err, repository.ErrAlreadyReported):
+		utils.WriteError(w, http.StatusConflict, err.Error(), err)
+	default:
+		fmt.Printf("❌ Handler Error: %v\n", err)
+		utils.WriteError(w, http.StatusInternalServerError, message, err)
+	}
+}
+
+// decodeModerationRequest parse body { "reason": "..." } - body kosong diperbolehkan
+func decodeModerationRequest(r *http.Request) (models.ModerationActionRequest, error) {
+	var req models.ModerationActionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		return req, err
+	}
+	return req, nil
+}
+
+// ReportReview adalah handler untuk endpoint POST /api/reviews/{id}/report
+// Protected route - body: { "reason": "spam|offensive|harassment|spoiler|other", "details": "..." }
+// 1 report per user per review, tidak bisa report review sendiri
+func (h *ModerationHandler) ReportReview(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get user dari context
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 3. Get review ID & parse request body
+	reviewID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid review ID format", err)
+		return
+	}
+	var req models.ReviewReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	// 4. Call service
+	report, err := h.moderationService.ReportReview(user.UserID, reviewID, req)
+	if err != nil {
+		writeModerationError(w, err, "Failed to report review")
+		return
+	}
+
+	// 5. Return response
+	utils.WriteSuccess(w, "Review reported successfully", report)
+}
+
+// GetReports adalah handler untuk endpoint GET /api/admin/moderation/reports
+// Moderator only - query param: status (open default, resolved, dismissed, all), page, limit
+// Return: ModerationQueueResponse (terlama dulu, beserta review & author yang dilaporkan)
+func (h *ModerationHandler) GetReports(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Parse query params
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = models.ReportStatusOpen
+	}
+	page, limit := parsePagination(r, 20, 100)
+
+	// 3. Call service
+	reports, total, err := h.moderationService.GetReports(status, page, limit)
+	if err != nil {
+		writeModerationError(w, err, "Failed to fetch reports")
+		return
+	}
+
+	// 4. Return response
+	utils.WriteSuccess(w, "Reports retrieved successfully", models.ModerationQueueResponse{
+		Status:     status,
+		Reports:    reports,
+		Pagination: newPaginationInfo(page, limit, total),
+	})
+}
+
+// moderate adalah helper untuk semua moderation action endpoints:
+// ambil moderator dari context, parse {id} dan body reason, lalu jalankan action
+func (h *ModerationHandler) moderate(w http.ResponseWriter, r *http.Request, successMessage string, errorMessage string,
+	action func(moderatorID int, id int, req models.ModerationActionRequest) (*models.ModerationAction, error)) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get moderator dari context
+	moderator, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 3. Get ID dari URL path & parse request body
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid ID format", err)
+		return
+	}
+	req, err := decodeModerationRequest(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	// 4. Jalankan action
+	result, err := action(moderator.UserID, id, req)
+	if err != nil {
+		writeModerationError(w, err, errorMessage)
+		return
+	}
+
+	// 5. Return response
+	utils.WriteSuccess(w, successMessage, result)
+}
+
+// HideReview adalah handler untuk endpoint POST /api/admin/moderation/reviews/{id}/hide
+// Review hilang dari public listings (tetap terlihat oleh author), open reports jadi resolved
+func (h *ModerationHandler) HideReview(w http.ResponseWriter, r *http.Request) {
+	h.moderate(w, r, "Review hidden successfully", "Failed to hide review", h.moderationService.HideReview)
+}
+
+// RestoreReview adalah handler untuk endpoint POST /api/admin/moderation/reviews/{id}/restore
+// Review tampil kembali di public listings, open reports jadi dismissed
+func (h *ModerationHandler) RestoreReview(w http.ResponseWriter, r *http.Request) {
+	h.moderate(w, r, "Review restored successfully", "Failed to restore review", h.moderationService.RestoreReview)
+}
+
+// DeleteReview adalah handler untuk endpoint DELETE /api/admin/moderation/reviews/{id}
+// Hapus review permanen (tercatat di audit trail)
+func (h *ModerationHandler) DeleteReview(w http.ResponseWriter, r *http.Request) {
+	h.moderate(w, r, "Review deleted successfully", "Failed to delete review", h.moderationService.DeleteReview)
+}
+
+// DismissReport adalah handler untuk endpoint POST /api/admin/moderation/reports/{id}/dismiss
+func (h *ModerationHandler) DismissReport(w http.ResponseWriter, r *http.Request) {
+	h.moderate(w, r, "Report dismissed successfully", "Failed to dismiss report", h.moderationService.DismissReport)
+}
+
+// WarnUser adalah handler untuk endpoint POST /api/admin/moderation/users/{id}/warn
+func (h *ModerationHandler) WarnUser(w http.ResponseWriter, r *http.Request) {
+	h.moderate(w, r, "User warned successfully", "Failed to warn user",
+		func(moderatorID int, userID int, req models.ModerationActionRequest) (*models.ModerationAction, error) {
+			return h.moderationService.ModerateUser(moderatorID, userID, models.ModerationWarnUser, req)
+		})
+}
+
+// SuspendUser adalah handler untuk endpoint POST /api/admin/moderation/users/{id}/suspend
+// Set Users.is_active = 0 - user tidak bisa login dan token-nya langsung ditolak
+func (h *ModerationHandler) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	h.moderate(w, r, "User suspended successfully", "Failed to suspend user",
+		func(moderatorID int, userID int, req models.ModerationActionRequest) (*models.ModerationAction, error) {
+			return h.moderationService.ModerateUser(moderatorID, userID, models.ModerationSuspendUser, req)
+		})
+}
+
+// ReinstateUser adalah handler untuk endpoint POST /api/admin/moderation/users/{id}/reinstate
+// Set Users.is_active = 1
+func (h *ModerationHandler) ReinstateUser(w http.ResponseWriter, r *http.Request) {
+	h.moderate(w, r, "User reinstated successfully", "Failed to reinstate user",
+		func(moderatorID int, userID int, req models.ModerationActionRequest) (*models.ModerationAction, error) {
+			return h.moderationService.ModerateUser(moderatorID, userID, models.ModerationReinstateUser, req)
+		})
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"film-dashboard-api/internal/models"
+)
+
+// parsePagination membaca query param page & limit dengan default dan batas maksimal
+// page default 1, limit default defaultLimit (kalau > maxLimit, dipotong ke maxLimit)
+func parsePagination(r *http.Request, defaultLimit int, maxLimit int) (int, int) {
+	page := 1
+	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	limit := defaultLimit
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+		limit = l
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	return page, limit
+}
+
+// newPaginationInfo membuat PaginationInfo dari page, limit, dan total rows
+func newPaginationInfo(page int, limit int, total int) *models.PaginationInfo {
+	return &models.PaginationInfo{
+		Page:      page,
+		Limit:     limit,
+		Total:     total,
+		TotalPage: (total + limit - 1) / limit,
+		HasNext:   page*limit < total,
+	}
+}
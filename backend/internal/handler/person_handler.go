@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"film-dashboard-api/internal/models"
+	"film-dashboard-api/internal/repository"
+	"film-dashboard-api/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// PersonHandler adalah struct yang berisi semua handler untuk person (cast & crew) operations
+type PersonHandler struct {
+	personRepo *repository.PersonRepository
+}
+
+// NewPersonHandler adalah constructor untuk bikin instance PersonHandler
+func NewPersonHandler(personRepo *repository.PersonRepository) *PersonHandler {
+	return &PersonHandler{
+		personRepo: personRepo,
+	}
+}
+
+// GetPersonDetail adalah handler untuk endpoint GET /api/persons/{id}
+// Query param: credits (jumlah credits per category di filmography, default 10, max 50)
+// Return: PersonDetailResponse (person, professions, known-for, filmography per category)
+func (h *PersonHandler) GetPersonDetail(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight OPTIONS request
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Check method GET
+	if r.Method != http.MethodGet {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed", nil)
+		return
+	}
+
+	// 3. Get person_id dari URL path
+	personID := mux.Vars(r)["id"]
+	if personID == "" {
+		utils.WriteError(w, http.StatusBadRequest, "Person ID is required", nil)
+		return
+	}
+
+	creditsPerGroup := 10
+	if c, err := strconv.Atoi(r.URL.Query().Get("credits")); err == nil && c > 0 {
+		creditsPerGroup = c
+	}
+	if creditsPerGroup > 50 {
+		creditsPerGroup = 50
+	}
+
+	// 4. Call repository untuk get person detail
+	detail, err := h.personRepo.GetPersonDetail(personID, creditsPerGroup)
+	if err != nil {
+		fmt.Printf("❌ Handler Error: %v\n", err)
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch person detail", err)
+		return
+	}
+
+	// 5. Check if person exists
+	if detail == nil {
+		utils.WriteError(w, http.StatusNotFound, "Person not found", nil)
+		return
+	}
+
+	// 6. Return success response
+	utils.WriteSuccess(w, "Person detail retrieved successfully", detail)
+}
+
+// GetFilmography adalah handler untuk endpoint GET /api/persons/{id}/filmography
+// Query param: category (optional, contoh: actor, director, writer), page (default 1), limit (default 20, max 100)
+// Return: FilmographyResponse dengan credits dan pagination info
+func (h *PersonHandler) GetFilmography(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight OPTIONS request
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Check method GET
+	if r.Method != http.MethodGet {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed", nil)
+		return
+	}
+
+	// 3. Get person_id dan query params
+	personID := mux.Vars(r)["id"]
+	if personID == "" {
+		utils.WriteError(w, http.StatusBadRequest, "Person ID is required", nil)
+		return
+	}
+	category := r.URL.Query().Get("category")
+	page, limit := parsePagination(r, 20, 100)
+
+	// 4. Call repository untuk get filmography
+	credits, total, err := h.personRepo.GetFilmography(personID, category, page, limit)
+	if errors.Is(err, repository.ErrPersonNotFound) {
+		utils.WriteError(w, http.StatusNotFound, "Person not found", nil)
+		return
+	}
+	if err != nil {
+		fmt.Printf("❌ Handler Error: %v\n", err)
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch filmography", err)
+		return
+	}
+
+	// 5. Return success response
+	utils.WriteSuccess(w, "Filmography retrieved successfully", models.FilmographyResponse{
+		Category:   category,
+		Credits:    credits,
+		Pagination: newPaginationInfo(page, limit, total),
+	})
+}
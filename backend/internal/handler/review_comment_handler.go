@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"film-dashboard-api/internal/middleware"
+	"film-dashboard-api/internal/models"
+	"film-dashboard-api/internal/repository"
+	"film-dashboard-api/internal/service"
+	"film-dashboard-api/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// writeCommentError mapping error dari comment operations ke HTTP status
+// (validation 400, bukan owner 403, review/comment not found 404, lainnya 500)
+func writeCommentError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case service.IsValidationError(err):
+		utils.WriteError(w, http.StatusBadRequest, err.Error(), err)
+	case errors.Is(err, repository.ErrCommentForbidden):
+		utils.WriteError(w, http.StatusForbidden, err.Error(), err)
+	case errors.Is(err, repository.ErrReviewNotFound),
+		errors.Is(err, repository.ErrCommentNotFound):
+		utils.WriteError(w, http.StatusNotFound, err.Error(), err)
+	default:
+		fmt.Printf("❌ Handler Error: %v\n", err)
+		utils.WriteError(w, http.StatusInternalServerError, message, err)
+	}
+}
+
+// GetComments adalah handler untuk endpoint GET /api/reviews/{id}/comments
+// Public route - query param: parent (comment_id, optional - tanpa parent = top-level comments)
+// Query param: page (default 1), limit (default 20, max 100)
+// Review yang di-hide moderator return 404, kecuali untuk author / moderator (lewat OptionalAuth)
+// Return: ReviewCommentListResponse (terlama dulu + reply_count per comment untuk load replies)
+func (h *ReviewHandler) GetComments(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get review ID dari URL path & parent dari query param
+	reviewID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid review ID format", err)
+		return
+	}
+	var parentID *int
+	if parentStr := r.URL.Query().Get("parent"); parentStr != "" {
+		parsed, err := strconv.Atoi(parentStr)
+		if err != nil {
+			utils.WriteError(w, http.StatusBadRequest, "Invalid parent comment ID format", err)
+			return
+		}
+		parentID = &parsed
+	}
+
+	// 3. Call service (viewer optional, dipakai untuk akses comments review yang di-hide)
+	viewerID, isModerator := 0, false
+	if viewer, ok := middleware.GetUserFromContext(r.Context()); ok {
+		viewerID, isModerator = viewer.UserID, h.isModerator(viewer.RoleName)
+	}
+	page, limit := parsePagination(r, 20, 100)
+	comments, total, err := h.reviewService.GetComments(viewerID, isModerator, reviewID, parentID, page, limit)
+	if err != nil {
+		writeCommentError(w, err, "Failed to fetch comments")
+		return
+	}
+
+	// 4. Return response
+	utils.WriteSuccess(w, "Comments retrieved successfully", models.ReviewCommentListResponse{
+		ReviewID:        reviewID,
+		ParentCommentID: parentID,
+		Comments:        comments,
+		Pagination:      newPaginationInfo(page, limit, total),
+	})
+}
+
+// CreateComment adalah handler untuk endpoint POST /api/reviews/{id}/comments
+// Protected route - body: { "body": "...", "parent_comment_id": 123 (optional, untuk reply) }
+func (h *ReviewHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get user dari context
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 3. Get review ID & parse request body
+	reviewID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid review ID format", err)
+		return
+	}
+	var req models.ReviewCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	// 4. Call service
+	comment, err := h.reviewService.CreateComment(user.UserID, reviewID, req)
+	if err != nil {
+		writeCommentError(w, err, "Failed to create comment")
+		return
+	}
+
+	// 5. Return response
+	utils.WriteSuccess(w, "Comment created successfully", comment)
+}
+
+// UpdateComment adalah handler untuk endpoint PUT /api/reviews/comments/{id}
+// Protected route - body: { "body": "..." }. Hanya owner yang bisa edit
+func (h *ReviewHandler) UpdateComment(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get user dari context
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 3. Get comment ID & parse request body
+	commentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid comment ID format", err)
+		return
+	}
+	var req models.ReviewCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	// 4. Call service (repository verify ownership)
+	comment, err := h.reviewService.UpdateComment(user.UserID, commentID, req)
+	if err != nil {
+		writeCommentError(w, err, "Failed to update comment")
+		return
+	}
+
+	// 5. Return response
+	utils.WriteSuccess(w, "Comment updated successfully", comment)
+}
+
+// DeleteComment adalah handler untuk endpoint DELETE /api/reviews/comments/{id}
+// Protected route - soft-delete, hanya owner yang bisa delete
+func (h *ReviewHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get user dari context
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 3. Get comment ID dari URL path
+	commentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid comment ID format", err)
+		return
+	}
+
+	// 4. Call service (repository verify ownership)
+	if err := h.reviewService.DeleteComment(user.UserID, commentID); err != nil {
+		writeCommentError(w, err, "Failed to delete comment")
+		return
+	}
+
+	// 5. Return response
+	utils.WriteSuccess(w, "Comment deleted successfully", nil)
+}
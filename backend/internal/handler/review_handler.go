@@ -2,12 +2,15 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"film-dashboard-api/internal/middleware"
 	"film-dashboard-api/internal/models"
+	"film-dashboard-api/internal/repository"
 	"film-dashboard-api/internal/service"
 	"film-dashboard-api/internal/utils"
 
@@ -16,19 +19,24 @@ import (
 
 // ReviewHandler adalah struct yang berisi semua handler untuk review operations
 type ReviewHandler struct {
-	reviewService *service.ReviewService
+	reviewService  *service.ReviewService
+	moderatorRoles []string // role yang boleh melihat edit history review user lain
 }
 
 // NewReviewHandler adalah constructor untuk bikin instance ReviewHandler
-func NewReviewHandler(reviewService *service.ReviewService) *ReviewHandler {
+func NewReviewHandler(reviewService *service.ReviewService, moderatorRoles []string) *ReviewHandler {
 	return &ReviewHandler{
-		reviewService: reviewService,
+		reviewService:  reviewService,
+		moderatorRoles: moderatorRoles,
 	}
 }
 
 // CreateOrUpdateReview adalah handler untuk endpoint POST /api/reviews
 // Protected route - butuh JWT token
 // Jika user sudah review title ini, akan update; jika belum, akan create
+// Body: { "title_id": "...", "rating": 1-10, "review_text": "...", "contains_spoilers": false }
+// review_text di-sanitize (HTML/script di-strip) dan dicek terhadap blocked words
+// title_id harus ada di catalog (404 kalau tidak ada), episode ditolak kecuali REVIEW_ALLOW_EPISODES=true
 func (h *ReviewHandler) CreateOrUpdateReview(w http.ResponseWriter, r *http.Request) {
 	// 1. Handle CORS preflight
 	if r.Method == http.MethodOptions {
@@ -60,7 +68,16 @@ func (h *ReviewHandler) CreateOrUpdateReview(w http.ResponseWriter, r *http.Requ
 	// 5. Call service untuk create/update review
 	response, err := h.reviewService.CreateOrUpdateReview(user.UserID, req)
 	if err != nil {
-		utils.WriteError(w, http.StatusBadRequest, err.Error(), err)
+		if service.IsValidationError(err) {
+			utils.WriteError(w, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+		if errors.Is(err, repository.ErrTitleNotFound) {
+			utils.WriteError(w, http.StatusNotFound, err.Error(), err)
+			return
+		}
+		fmt.Printf("❌ Handler Error: %v\n", err)
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to create/update review", err)
 		return
 	}
 
@@ -69,7 +86,11 @@ func (h *ReviewHandler) CreateOrUpdateReview(w http.ResponseWriter, r *http.Requ
 }
 
 // GetReviewsByTitle adalah handler untuk endpoint GET /api/reviews/{title}
-// Public route - tidak butuh authentication
+// Public route - tidak butuh authentication (OptionalAuth: kalau login, my_vote ikut terisi)
+// Query param: sort - newest (default), oldest, highest, lowest, helpful (most helpful)
+// Query param: spoilers=true untuk menampilkan review_text review yang ditandai spoiler (default di-redact)
+// Query param: page (default 1), limit (default 20, max 100)
+// Return: ReviewListResponse (reviews + summary average/count/histogram + pagination)
 func (h *ReviewHandler) GetReviewsByTitle(w http.ResponseWriter, r *http.Request) {
 	// 1. Handle CORS preflight
 	if r.Method == http.MethodOptions {
@@ -92,19 +113,36 @@ func (h *ReviewHandler) GetReviewsByTitle(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// 4. Call service untuk get reviews
-	reviews, err := h.reviewService.GetReviewsByTitle(titleID)
+	// 4. Call service untuk get reviews + summary
+	viewerID := 0
+	if viewer, ok := middleware.GetUserFromContext(r.Context()); ok {
+		viewerID = viewer.UserID
+	}
+	sort := parseReviewSort(r)
+	page, limit := parsePagination(r, 20, 100)
+	showSpoilers, _ := strconv.ParseBool(r.URL.Query().Get("spoilers"))
+	reviews, summary, total, err := h.reviewService.GetReviewsByTitle(viewerID, titleID, sort, showSpoilers, page, limit)
 	if err != nil {
+		if service.IsValidationError(err) {
+			utils.WriteError(w, http.StatusBadRequest, err.Error(), err)
+			return
+		}
 		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch reviews", err)
 		return
 	}
 
-	// 5. Return response (return empty array jika tidak ada review)
-	utils.WriteSuccess(w, "Reviews retrieved successfully", reviews)
+	// 5. Return response (reviews berupa empty array jika tidak ada review)
+	utils.WriteSuccess(w, "Reviews retrieved successfully", models.ReviewListResponse{
+		Reviews:    reviews,
+		Sort:       sort,
+		Summary:    summary,
+		Pagination: newPaginationInfo(page, limit, total),
+	})
 }
 
-// GetUserReviews adalah handler untuk endpoint GET /api/reviews/user/{id}
+// GetUserReviews adalah handler untuk endpoint GET /api/reviews/user
 // Protected route - hanya user yang sedang login bisa get review mereka sendiri
+// Query param: sort, page, limit (sama dengan GetReviewsByTitle)
 func (h *ReviewHandler) GetUserReviews(w http.ResponseWriter, r *http.Request) {
 	// 1. Handle CORS preflight
 	if r.Method == http.MethodOptions {
@@ -126,14 +164,33 @@ func (h *ReviewHandler) GetUserReviews(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 4. Call service untuk get user reviews
-	reviews, err := h.reviewService.GetReviewsByUser(user.UserID)
+	sort := parseReviewSort(r)
+	page, limit := parsePagination(r, 20, 100)
+	reviews, total, err := h.reviewService.GetReviewsByUser(user.UserID, sort, page, limit)
 	if err != nil {
+		if service.IsValidationError(err) {
+			utils.WriteError(w, http.StatusBadRequest, err.Error(), err)
+			return
+		}
 		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch reviews", err)
 		return
 	}
 
 	// 5. Return response
-	utils.WriteSuccess(w, "User reviews retrieved successfully", reviews)
+	utils.WriteSuccess(w, "User reviews retrieved successfully", models.ReviewListResponse{
+		Reviews:    reviews,
+		Sort:       sort,
+		Pagination: newPaginationInfo(page, limit, total),
+	})
+}
+
+// parseReviewSort membaca query param sort (default "newest")
+func parseReviewSort(r *http.Request) string {
+	sort := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("sort")))
+	if sort == "" {
+		return "newest"
+	}
+	return sort
 }
 
 // DeleteReview adalah handler untuk endpoint DELETE /api/reviews/{id}
@@ -234,3 +291,93 @@ func (h *ReviewHandler) GetUserReviewForTitle(w http.ResponseWriter, r *http.Req
 	// 6. Return response (review bisa nil jika belum ada review)
 	utils.WriteSuccess(w, "User review retrieved successfully", review)
 }
+
+// writeReviewVoteError mapping error dari vote operations ke HTTP status
+// (validation 400, own review 403, review not found 404, lainnya 500)
+func writeReviewVoteError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case service.IsValidationError(err):
+		utils.WriteError(w, http.StatusBadRequest, err.Error(), err)
+	case errors.Is(err, service.ErrOwnReviewVote):
+		utils.WriteError(w, http.StatusForbidden, err.Error(), err)
+	case errors.Is(err, repository.ErrReviewNotFound):
+		utils.WriteError(w, http.StatusNotFound, err.Error(), err)
+	default:
+		fmt.Printf("❌ Handler Error: %v\n", err)
+		utils.WriteError(w, http.StatusInternalServerError, message, err)
+	}
+}
+
+// VoteReview adalah handler untuk endpoint PUT /api/reviews/{id}/vote
+// Protected route - body: { "vote": "helpful" | "not_helpful" }
+// 1 vote per user per review (vote baru mengganti vote lama), tidak bisa vote review sendiri
+func (h *ReviewHandler) VoteReview(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get user dari context
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 3. Get review ID & parse request body
+	reviewID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid review ID format", err)
+		return
+	}
+	var req models.ReviewVoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	// 4. Call service
+	summary, err := h.reviewService.VoteReview(user.UserID, reviewID, req)
+	if err != nil {
+		writeReviewVoteError(w, err, "Failed to vote on review")
+		return
+	}
+
+	// 5. Return response
+	utils.WriteSuccess(w, "Vote saved successfully", summary)
+}
+
+// RemoveReviewVote adalah handler untuk endpoint DELETE /api/reviews/{id}/vote
+// Protected route - idempotent
+func (h *ReviewHandler) RemoveReviewVote(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get user dari context
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 3. Get review ID dari URL path
+	reviewID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid review ID format", err)
+		return
+	}
+
+	// 4. Call service
+	summary, err := h.reviewService.RemoveReviewVote(user.UserID, reviewID)
+	if err != nil {
+		writeReviewVoteError(w, err, "Failed to remove vote")
+		return
+	}
+
+	// 5. Return response
+	utils.WriteSuccess(w, "Vote removed successfully", summary)
+}
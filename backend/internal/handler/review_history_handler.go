@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"film-dashboard-api/internal/middleware"
+	"film-dashboard-api/internal/repository"
+	"film-dashboard-api/internal/service"
+	"film-dashboard-api/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// GetReviewHistory adalah handler untuk endpoint GET /api/reviews/{id}/history
+// Protected route - hanya author review atau moderator (role di MODERATOR_ROLES)
+// Return: ReviewHistoryResponse (versi sekarang + versi lama, terbaru dulu)
+func (h *ReviewHandler) GetReviewHistory(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get user dari context
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 3. Get review ID dari URL path
+	reviewID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid review ID format", err)
+		return
+	}
+
+	// 4. Call service (service cek author / moderator)
+	history, err := h.reviewService.GetReviewHistory(user.UserID, h.isModerator(user.RoleName), reviewID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrReviewHistoryForbidden):
+			utils.WriteError(w, http.StatusForbidden, err.Error(), err)
+		case errors.Is(err, repository.ErrReviewNotFound):
+			utils.WriteError(w, http.StatusNotFound, err.Error(), err)
+		default:
+			fmt.Printf("❌ Handler Error: %v\n", err)
+			utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch review history", err)
+		}
+		return
+	}
+
+	// 5. Return response
+	utils.WriteSuccess(w, "Review history retrieved successfully", history)
+}
+
+// isModerator return true kalau roleName termasuk moderator roles
+func (h *ReviewHandler) isModerator(roleName string) bool {
+	for _, role := range h.moderatorRoles {
+		if roleName == role {
+			return true
+		}
+	}
+	return false
+}
@@ -2,30 +2,41 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 
+	"film-dashboard-api/internal/middleware"
 	"film-dashboard-api/internal/models"
 	"film-dashboard-api/internal/repository"
+	"film-dashboard-api/internal/service"
 	"film-dashboard-api/internal/utils"
+
+	"github.com/gorilla/mux"
 )
 
 // TitleHandler adalah struct yang berisi semua handler untuk title/film operations
 type TitleHandler struct {
-	titleRepo *repository.TitleRepository
+	titleRepo       *repository.TitleRepository
+	searchService   *service.SearchService
+	trendingService *service.TrendingService
+	viewTracker     *service.ViewTracker
 }
 
 // NewTitleHandler adalah constructor untuk bikin instance TitleHandler
-func NewTitleHandler(titleRepo *repository.TitleRepository) *TitleHandler {
+func NewTitleHandler(titleRepo *repository.TitleRepository, searchService *service.SearchService, trendingService *service.TrendingService, viewTracker *service.ViewTracker) *TitleHandler {
 	return &TitleHandler{
-		titleRepo: titleRepo,
+		titleRepo:       titleRepo,
+		searchService:   searchService,
+		trendingService: trendingService,
+		viewTracker:     viewTracker,
 	}
 }
 
 // GetTrendingTitles adalah handler untuk endpoint GET /api/titles/trending
-// Query param: limit (default 6)
-// Return: array of trending titles
+// Query param: window - day, week (default), month; limit (default 6, max 50)
+// Return: array of trending titles (ranking dari reviews, watchlist adds & detail views dengan time decay)
 func (h *TitleHandler) GetTrendingTitles(w http.ResponseWriter, r *http.Request) {
 	// 1. Handle CORS preflight OPTIONS request
 	if r.Method == http.MethodOptions {
@@ -39,7 +50,11 @@ func (h *TitleHandler) GetTrendingTitles(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// 3. Get limit dari query param (default 6)
+	// 3. Get window & limit dari query param (default week, 6)
+	window := r.URL.Query().Get("window")
+	if window == "" {
+		window = service.DefaultTrendingWindow
+	}
 	limitStr := r.URL.Query().Get("limit")
 	limit := 6
 	if limitStr != "" {
@@ -47,15 +62,22 @@ func (h *TitleHandler) GetTrendingTitles(w http.ResponseWriter, r *http.Request)
 			limit = l
 		}
 	}
+	if limit > 50 {
+		limit = 50
+	}
 
-	// 4. Call repository untuk get trending titles
-	titles, err := h.titleRepo.GetTrendingTitles(limit)
+	// 4. Call service untuk get trending titles
+	titles, err := h.trendingService.GetTrending(window, limit)
 	if err != nil {
+		if service.IsValidationError(err) {
+			utils.WriteError(w, http.StatusBadRequest, err.Error(), err)
+			return
+		}
 		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch trending titles", err)
 		return
 	}
 
-	// 5. Return success response
+	// 5. Return success response (trending_source per title: activity / popularity)
 	utils.WriteSuccess(w, "Trending titles retrieved successfully", titles)
 }
 
@@ -96,8 +118,13 @@ func (h *TitleHandler) GetTopRatedTitles(w http.ResponseWriter, r *http.Request)
 }
 
 // SearchTitles adalah handler untuk endpoint GET /api/titles/search
-// Query param: q (search keyword) - required
-// Return: array of matching titles
+// Query param: q (search keyword) - required, boleh berisi structured syntax
+// (contoh: genre:drama year:2010..2015 rating:>8 type:series "breaking" - lihat service/search_query.go)
+// Query param: page (default 1), limit (default 20, max 100), cursor (optional)
+// Query param: sortBy - relevance (default, rank full-text), popularity, rating, votes, released, name, community
+// Semua filter FilterRequest juga bisa dikirim sebagai query param (genreIds, typeIds, yearFrom, ratingMin, ...)
+// Query param: includeFacets=true (optional) - facet counts tidak dihitung secara default
+// Return: FilterResponse (sama dengan POST /api/titles/filter) dengan total count dan pagination
 func (h *TitleHandler) SearchTitles(w http.ResponseWriter, r *http.Request) {
 	// 1. Handle CORS preflight OPTIONS request
 	if r.Method == http.MethodOptions {
@@ -111,33 +138,59 @@ func (h *TitleHandler) SearchTitles(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 3. Get search keyword dari query param
-	keyword := r.URL.Query().Get("q")
-	if keyword == "" {
+	// 3. Parse search keyword, filters, dan pagination dari query params
+	filterReq, err := parseFilterQuery(r.URL.Query())
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	query := filterReq.Q
+	if query == "" {
 		utils.WriteError(w, http.StatusBadRequest, "Search keyword (q) is required", nil)
 		return
 	}
 
-	fmt.Println("=== SEARCH REQUEST ===")
-	fmt.Printf("Endpoint: /api/titles/search\n")
-	fmt.Printf("Query Param 'q': %s\n", keyword)
-
-	// 4. Call repository untuk search titles
-	titles, err := h.titleRepo.SearchTitles(keyword)
-	if err != nil {
+	// Compile structured syntax (filters di q digabung dengan filters dari query params)
+	if err := h.searchService.CompileQuery(query, filterReq); err != nil {
+		var queryErr *models.SearchQueryError
+		if errors.As(err, &queryErr) {
+			utils.WriteJSON(w, http.StatusBadRequest, models.SearchQueryErrorResponse{
+				Success: false,
+				Message: "Invalid search query",
+				Error:   queryErr.Error(),
+				Query:   query,
+				Details: queryErr,
+			})
+			return
+		}
 		fmt.Printf("❌ Handler Error: %v\n", err)
 		utils.WriteError(w, http.StatusInternalServerError, "Failed to search titles", err)
 		return
 	}
 
-	// 5. Return success response
-	fmt.Printf("📤 Returning %d results to frontend\n", len(titles))
-	fmt.Println("====================\n")
-	utils.WriteSuccess(w, "Search results retrieved successfully", titles)
+	filterReq.Page, filterReq.Limit = parsePagination(r, 20, 100)
+	if filterReq.SortBy == "" {
+		filterReq.SortBy = "relevance" // Default ranking untuk search
+		if filterReq.Q == "" {
+			filterReq.SortBy = "votes" // Query hanya berisi filters, tidak ada rank full-text
+		}
+	}
+	if err := filterReq.Validate(); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	fmt.Println("=== SEARCH REQUEST ===")
+	fmt.Printf("Endpoint: /api/titles/search\n")
+	fmt.Printf("Query Param 'q': %s, SortBy: %s, Page: %d, Limit: %d\n", filterReq.Q, filterReq.SortBy, filterReq.Page, filterReq.Limit)
+
+	// 4. Call repository (query yang sama dengan filter) dan return response
+	h.writeFilterResults(w, filterReq, "Failed to search titles")
 }
 
 // GetTitleDetail adalah handler untuk endpoint GET /api/titles/{id}/detail
 // Path param: id (title_id)
+// Setiap request yang berhasil dicatat sebagai detail view (signal trending)
 // Return: TitleDetailResponse dengan semua informasi detail
 func (h *TitleHandler) GetTitleDetail(w http.ResponseWriter, r *http.Request) {
 	// 1. Handle CORS preflight OPTIONS request
@@ -166,7 +219,7 @@ func (h *TitleHandler) GetTitleDetail(w http.ResponseWriter, r *http.Request) {
 	fmt.Printf("Endpoint: /api/titles/%s/detail\n", titleID)
 
 	// 4. Call repository untuk get title detail
-	detail, err := h.titleRepo.GetTitleDetail(titleID)
+	detail, err := h.titleRepo.GetTitleDetail(r.Context(), titleID)
 	if err != nil {
 		fmt.Printf("❌ Handler Error: %v\n", err)
 		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch title detail", err)
@@ -176,17 +229,151 @@ func (h *TitleHandler) GetTitleDetail(w http.ResponseWriter, r *http.Request) {
 	// 5. Check if title exists (detail should not be nil)
 	if detail == nil || detail.Detail == nil {
 		fmt.Printf("❌ Movie not found: %s\n", titleID)
-		fmt.Println("================================\n")
+		fmt.Println("================================")
 		utils.WriteError(w, http.StatusNotFound, "Movie not found", nil)
 		return
 	}
 
-	// 6. Return success response
-	fmt.Printf("📤 Returning detail for title: %s\n", titleID)
-	fmt.Println("================================\n")
+	// 6. Record view untuk trending & analytics (di-buffer, tidak menunggu database)
+	// user_id terisi kalau login (lewat OptionalAuth)
+	var viewerID *int
+	if viewer, ok := middleware.GetUserFromContext(r.Context()); ok {
+		viewerID = &viewer.UserID
+	}
+	h.viewTracker.Record(titleID, viewerID, middleware.ClientIP(r), r.UserAgent())
+
+	// 7. Return success response (detail.Partial = true kalau ada section yang gagal)
+	fmt.Printf("📤 Returning detail for title: %s (failed sections: %v)\n", titleID, detail.FailedSections)
+	fmt.Println("================================")
 	utils.WriteSuccess(w, "Title detail retrieved successfully", detail)
 }
 
+// GetSeasons adalah handler untuk endpoint GET /api/titles/{id}/seasons
+// Path param: id (title_id dari series)
+// Return: array of Season (season number, jumlah episode, air dates, rata-rata rating)
+func (h *TitleHandler) GetSeasons(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight OPTIONS request
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Check method GET
+	if r.Method != http.MethodGet {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed", nil)
+		return
+	}
+
+	// 3. Get title_id dari URL path
+	titleID := mux.Vars(r)["id"]
+	if titleID == "" {
+		utils.WriteError(w, http.StatusBadRequest, "Title ID is required", nil)
+		return
+	}
+
+	// 4. Call repository untuk get seasons
+	seasons, err := h.titleRepo.GetSeasons(titleID)
+	if err != nil {
+		fmt.Printf("❌ Handler Error: %v\n", err)
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch seasons", err)
+		return
+	}
+	if seasons == nil {
+		utils.WriteError(w, http.StatusNotFound, "Title not found", nil)
+		return
+	}
+
+	// 5. Return success response
+	utils.WriteSuccess(w, "Seasons retrieved successfully", seasons)
+}
+
+// GetSeasonEpisodes adalah handler untuk endpoint GET /api/titles/{id}/seasons/{n}/episodes
+// Path param: id (title_id dari series), n (season number)
+// Return: array of Episode (title_id episode, nama, air date, rating, runtime)
+func (h *TitleHandler) GetSeasonEpisodes(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight OPTIONS request
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Check method GET
+	if r.Method != http.MethodGet {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed", nil)
+		return
+	}
+
+	// 3. Get title_id dan season number dari URL path
+	vars := mux.Vars(r)
+	titleID := vars["id"]
+	if titleID == "" {
+		utils.WriteError(w, http.StatusBadRequest, "Title ID is required", nil)
+		return
+	}
+	seasonNumber, err := strconv.Atoi(vars["n"])
+	if err != nil || seasonNumber < 0 {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid season number", err)
+		return
+	}
+
+	// 4. Call repository untuk get episodes
+	episodes, err := h.titleRepo.GetSeasonEpisodes(titleID, seasonNumber)
+	if err != nil {
+		fmt.Printf("❌ Handler Error: %v\n", err)
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch episodes", err)
+		return
+	}
+	if episodes == nil {
+		utils.WriteError(w, http.StatusNotFound, "Title not found", nil)
+		return
+	}
+
+	// 5. Return success response
+	utils.WriteSuccess(w, "Episodes retrieved successfully", episodes)
+}
+
+// GetAlternateTitles adalah handler untuk endpoint GET /api/titles/{id}/alternate-titles
+// Path param: id (title_id)
+// Query param: region, language (optional, exact match)
+// Return: array of AlternateTitle
+func (h *TitleHandler) GetAlternateTitles(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight OPTIONS request
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Check method GET
+	if r.Method != http.MethodGet {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed", nil)
+		return
+	}
+
+	// 3. Get title_id dan filter dari request
+	titleID := mux.Vars(r)["id"]
+	if titleID == "" {
+		utils.WriteError(w, http.StatusBadRequest, "Title ID is required", nil)
+		return
+	}
+	region := r.URL.Query().Get("region")
+	language := r.URL.Query().Get("language")
+
+	// 4. Call repository untuk get alternate titles
+	titles, err := h.titleRepo.GetAlternateTitles(titleID, region, language)
+	if err != nil {
+		fmt.Printf("❌ Handler Error: %v\n", err)
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch alternate titles", err)
+		return
+	}
+	if titles == nil {
+		utils.WriteError(w, http.StatusNotFound, "Title not found", nil)
+		return
+	}
+
+	// 5. Return success response
+	utils.WriteSuccess(w, "Alternate titles retrieved successfully", titles)
+}
+
 // GetFilterOptions adalah handler untuk endpoint GET /api/titles/filter-options
 // Return: FilterOptionsResponse dengan semua available filter options
 func (h *TitleHandler) GetFilterOptions(w http.ResponseWriter, r *http.Request) {
@@ -213,16 +400,17 @@ func (h *TitleHandler) GetFilterOptions(w http.ResponseWriter, r *http.Request)
 	}
 
 	// 4. Return success response
-	fmt.Printf("📤 Returning filter options: %d genres, %d types, %d statuses\n",
-		len(options.Genres), len(options.Types), len(options.Statuses))
-	fmt.Println("================================\n")
+	fmt.Printf("📤 Returning filter options: %d genres, %d types, %d statuses, %d origin countries, %d production countries\n",
+		len(options.Genres), len(options.Types), len(options.Statuses), len(options.OriginCountries), len(options.ProductionCountries))
+	fmt.Println("================================")
 	utils.WriteSuccess(w, "Filter options retrieved successfully", options)
 }
 
 // FilterTitles adalah handler untuk endpoint POST /api/titles/filter
 // Body: JSON dengan filter parameters (semua optional)
-// Query param: page, limit untuk pagination
-// Return: FilterResponse dengan array of filtered titles dan total count
+// Body juga berisi page, limit, dan optional cursor untuk pagination
+// includeFacets: true (dikirim oleh filter sidebar) untuk ikut menghitung facet counts
+// Return: FilterResponse dengan array of filtered titles, total count, pagination block, dan facet counts (opt-in)
 func (h *TitleHandler) FilterTitles(w http.ResponseWriter, r *http.Request) {
 	// 1. Handle CORS preflight OPTIONS request
 	if r.Method == http.MethodOptions {
@@ -255,44 +443,61 @@ func (h *TitleHandler) FilterTitles(w http.ResponseWriter, r *http.Request) {
 	if filterReq.SortBy == "" {
 		filterReq.SortBy = "released" // Default sort
 	}
-
-	// Calculate offset untuk pagination
-	offset := (filterReq.Page - 1) * filterReq.Limit
+	if err := filterReq.Validate(); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
 
 	fmt.Println("=== FILTER TITLES REQUEST ===")
-	fmt.Printf("Page: %d, Limit: %d, Offset: %d\n", filterReq.Page, filterReq.Limit, offset)
+	fmt.Printf("Page: %d, Limit: %d\n", filterReq.Page, filterReq.Limit)
 	fmt.Printf("Filters: GenreIDs=%v (%d), TypeIDs=%v (%d), StatusIDs=%v (%d), Year=%v, SortBy=%s\n",
-		filterReq.GenreIDs, len(filterReq.GenreIDs), filterReq.TypeIDs, len(filterReq.TypeIDs), 
+		filterReq.GenreIDs, len(filterReq.GenreIDs), filterReq.TypeIDs, len(filterReq.TypeIDs),
 		filterReq.StatusIDs, len(filterReq.StatusIDs), filterReq.Year, filterReq.SortBy)
 
-	// 5. Call repository untuk filter titles
-	titles, totalCount, err := h.titleRepo.FilterTitles(
-		filterReq.GenreIDs,
-		filterReq.TypeIDs,
-		filterReq.StatusIDs,
-		filterReq.OriginCountryIDs,
-		filterReq.ProductionCountryIDs,
-		filterReq.Year,
-		filterReq.SortBy,
-		offset,
-		filterReq.Limit,
-	)
+	// 5. Call repository untuk filter titles dan return response
+	h.writeFilterResults(w, &filterReq, "Failed to filter titles")
+}
+
+// writeFilterResults menjalankan FilterTitles (+ facets) dan menulis FilterResponse
+// Dipakai bersama oleh FilterTitles dan SearchTitles supaya response keduanya identik
+func (h *TitleHandler) writeFilterResults(w http.ResponseWriter, filterReq *models.FilterRequest, failMessage string) {
+	titles, pagination, err := h.titleRepo.FilterTitles(filterReq)
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid pagination cursor", err)
+		return
+	}
 	if err != nil {
 		fmt.Printf("❌ Handler Error: %v\n", err)
-		utils.WriteError(w, http.StatusInternalServerError, "Failed to filter titles", err)
+		utils.WriteError(w, http.StatusInternalServerError, failMessage, err)
 		return
 	}
+	if titles == nil {
+		titles = make([]*models.FilmCardData, 0)
+	}
 
-	// 6. Build response
+	// Build response
 	response := models.FilterResponse{
-		Success: true,
-		Data:    titles,
-		Count:   totalCount,
+		Success:    true,
+		Data:       titles,
+		Count:      pagination.Total,
+		Pagination: pagination,
+	}
+
+	// Facet counts opt-in (includeFacets) karena menambah 5 query agregasi per request,
+	// dan hanya untuk request non-cursor (cursor page = filter set yang sama)
+	// Kalau gagal, response tetap dikirim tanpa facets
+	if filterReq.IncludeFacets && filterReq.Cursor == "" {
+		facets, err := h.titleRepo.GetFilterFacets(filterReq)
+		if err != nil {
+			fmt.Printf("⚠️  Warning: Could not get filter facets: %v\n", err)
+		} else {
+			response.Facets = facets
+		}
 	}
 
-	// 7. Return success response
-	fmt.Printf("📤 Returning %d filtered titles (Total: %d)\n", len(titles), totalCount)
-	fmt.Println("=============================\n")
+	// Return success response
+	fmt.Printf("📤 Returning %d titles (Total: %d)\n", len(titles), pagination.Total)
+	fmt.Println("=============================")
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -0,0 +1,377 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"film-dashboard-api/internal/middleware"
+	"film-dashboard-api/internal/models"
+	"film-dashboard-api/internal/repository"
+	"film-dashboard-api/internal/service"
+	"film-dashboard-api/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// UserListHandler adalah struct yang berisi semua handler untuk user lists
+type UserListHandler struct {
+	listService *service.UserListService
+}
+
+// NewUserListHandler adalah constructor untuk bikin instance UserListHandler
+func NewUserListHandler(listService *service.UserListService) *UserListHandler {
+	return &UserListHandler{
+		listService: listService,
+	}
+}
+
+// writeListError mapping error dari UserListService ke HTTP status
+// (validation 400, forbidden 403, not found 404, duplicate 409, lainnya 500)
+func writeListError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case service.IsValidationError(err):
+		utils.WriteError(w, http.StatusBadRequest, err.Error(), err)
+	case errors.Is(err, repository.ErrListNotFound),
+		errors.Is(err, repository.ErrListEntryNotFound),
+		errors.Is(err, repository.ErrTitleNotFound):
+		utils.WriteError(w, http.StatusNotFound, err.Error(), err)
+	case errors.Is(err, service.ErrListForbidden):
+		utils.WriteError(w, http.StatusForbidden, err.Error(), err)
+	case errors.Is(err, repository.ErrListEntryExists):
+		utils.WriteError(w, http.StatusConflict, err.Error(), err)
+	default:
+		fmt.Printf("❌ Handler Error: %v\n", err)
+		utils.WriteError(w, http.StatusInternalServerError, message, err)
+	}
+}
+
+// listIDFromPath parse {id} dari URL path
+func listIDFromPath(r *http.Request) (int, error) {
+	return strconv.Atoi(mux.Vars(r)["id"])
+}
+
+// GetMyLists adalah handler untuk endpoint GET /api/lists
+// Protected route - return semua list milik user yang login (termasuk private)
+func (h *UserListHandler) GetMyLists(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get user dari context (di-set oleh Auth middleware)
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 3. Call service
+	lists, err := h.listService.GetMyLists(user.UserID)
+	if err != nil {
+		writeListError(w, err, "Failed to fetch lists")
+		return
+	}
+
+	// 4. Return response
+	utils.WriteSuccess(w, "Lists retrieved successfully", lists)
+}
+
+// CreateList adalah handler untuk endpoint POST /api/lists
+// Body: { "name": "...", "description": "...", "visibility": "public|unlisted|private" }
+func (h *UserListHandler) CreateList(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get user dari context
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 3. Parse request body
+	var req models.UserListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	// 4. Call service
+	list, err := h.listService.CreateList(user.UserID, req)
+	if err != nil {
+		writeListError(w, err, "Failed to create list")
+		return
+	}
+
+	// 5. Return response
+	utils.WriteSuccess(w, "List created successfully", list)
+}
+
+// UpdateList adalah handler untuk endpoint PUT /api/lists/{id}
+// Body sama dengan CreateList. Hanya owner yang bisa update
+func (h *UserListHandler) UpdateList(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get user dari context
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 3. Get list ID & parse request body
+	listID, err := listIDFromPath(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid list ID format", err)
+		return
+	}
+	var req models.UserListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	// 4. Call service
+	list, err := h.listService.UpdateList(user.UserID, listID, req)
+	if err != nil {
+		writeListError(w, err, "Failed to update list")
+		return
+	}
+
+	// 5. Return response
+	utils.WriteSuccess(w, "List updated successfully", list)
+}
+
+// DeleteList adalah handler untuk endpoint DELETE /api/lists/{id}
+// Hanya owner yang bisa delete
+func (h *UserListHandler) DeleteList(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get user dari context
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 3. Get list ID
+	listID, err := listIDFromPath(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid list ID format", err)
+		return
+	}
+
+	// 4. Call service
+	if err := h.listService.DeleteList(user.UserID, listID); err != nil {
+		writeListError(w, err, "Failed to delete list")
+		return
+	}
+
+	// 5. Return response
+	utils.WriteSuccess(w, "List deleted successfully", nil)
+}
+
+// GetListBySlug adalah handler untuk endpoint GET /api/lists/{slug}
+// Public route (OptionalAuth) - public & unlisted list bisa dibaca siapa saja,
+// private list hanya oleh owner-nya
+// Query param: page (default 1), limit (default 50, max 200)
+func (h *UserListHandler) GetListBySlug(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Pastikan method GET
+	if r.Method != http.MethodGet {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed", nil)
+		return
+	}
+
+	// 3. Get slug dan viewer (boleh tidak login)
+	slug := mux.Vars(r)["slug"]
+	if slug == "" {
+		utils.WriteError(w, http.StatusBadRequest, "List slug is required", nil)
+		return
+	}
+	viewer, _ := middleware.GetUserFromContext(r.Context())
+	page, limit := parsePagination(r, 50, 200)
+
+	// 4. Call service
+	list, entries, err := h.listService.GetListBySlug(slug, viewer, page, limit)
+	if err != nil {
+		writeListError(w, err, "Failed to fetch list")
+		return
+	}
+
+	// 5. Return response
+	utils.WriteSuccess(w, "List retrieved successfully", models.UserListDetailResponse{
+		List:       list,
+		Entries:    entries,
+		Pagination: newPaginationInfo(page, limit, list.EntryCount),
+	})
+}
+
+// AddEntry adalah handler untuk endpoint POST /api/lists/{id}/entries
+// Body: { "title_id": "...", "note": "..." } - title ditambahkan di akhir list
+func (h *UserListHandler) AddEntry(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get user dari context
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 3. Get list ID & parse request body
+	listID, err := listIDFromPath(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid list ID format", err)
+		return
+	}
+	var req models.UserListEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	// 4. Call service
+	list, err := h.listService.AddEntry(user.UserID, listID, req)
+	if err != nil {
+		writeListError(w, err, "Failed to add title to list")
+		return
+	}
+
+	// 5. Return response
+	utils.WriteSuccess(w, "Title added to list", list)
+}
+
+// UpdateEntry adalah handler untuk endpoint PUT /api/lists/{id}/entries/{title}
+// Body: { "note": "..." } - note null / kosong menghapus note
+func (h *UserListHandler) UpdateEntry(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get user dari context
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 3. Get list ID, title ID & parse request body
+	listID, err := listIDFromPath(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid list ID format", err)
+		return
+	}
+	titleID := mux.Vars(r)["title"]
+	var req models.UserListEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	// 4. Call service
+	if err := h.listService.UpdateEntryNote(user.UserID, listID, titleID, req.Note); err != nil {
+		writeListError(w, err, "Failed to update list entry")
+		return
+	}
+
+	// 5. Return response
+	utils.WriteSuccess(w, "List entry updated successfully", nil)
+}
+
+// RemoveEntry adalah handler untuk endpoint DELETE /api/lists/{id}/entries/{title}
+func (h *UserListHandler) RemoveEntry(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get user dari context
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 3. Get list ID & title ID
+	listID, err := listIDFromPath(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid list ID format", err)
+		return
+	}
+	titleID := mux.Vars(r)["title"]
+
+	// 4. Call service
+	if err := h.listService.RemoveEntry(user.UserID, listID, titleID); err != nil {
+		writeListError(w, err, "Failed to remove title from list")
+		return
+	}
+
+	// 5. Return response
+	utils.WriteSuccess(w, "Title removed from list", nil)
+}
+
+// ReorderEntries adalah handler untuk endpoint PUT /api/lists/{id}/order
+// Body: { "title_ids": ["...", "..."] } - semua title di list dalam urutan baru
+func (h *UserListHandler) ReorderEntries(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get user dari context
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 3. Get list ID & parse request body
+	listID, err := listIDFromPath(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid list ID format", err)
+		return
+	}
+	var req models.UserListOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	// 4. Call service
+	if err := h.listService.ReorderEntries(user.UserID, listID, req.TitleIDs); err != nil {
+		writeListError(w, err, "Failed to reorder list")
+		return
+	}
+
+	// 5. Return response
+	utils.WriteSuccess(w, "List reordered successfully", nil)
+}
@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"film-dashboard-api/internal/middleware"
+	"film-dashboard-api/internal/models"
+	"film-dashboard-api/internal/repository"
+	"film-dashboard-api/internal/service"
+	"film-dashboard-api/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// WatchHistoryHandler adalah struct yang berisi semua handler untuk watched history / viewing diary
+// Semua endpoint protected (butuh JWT token)
+type WatchHistoryHandler struct {
+	historyService *service.WatchHistoryService
+}
+
+// NewWatchHistoryHandler adalah constructor untuk bikin instance WatchHistoryHandler
+func NewWatchHistoryHandler(historyService *service.WatchHistoryService) *WatchHistoryHandler {
+	return &WatchHistoryHandler{
+		historyService: historyService,
+	}
+}
+
+// writeHistoryError mapping error dari WatchHistoryService ke HTTP status
+// (validation 400, not found 404, lainnya 500)
+func writeHistoryError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case service.IsValidationError(err):
+		utils.WriteError(w, http.StatusBadRequest, err.Error(), err)
+	case errors.Is(err, repository.ErrTitleNotFound),
+		errors.Is(err, repository.ErrWatchNotFound),
+		errors.Is(err, repository.ErrReviewNotOwned):
+		utils.WriteError(w, http.StatusNotFound, err.Error(), err)
+	default:
+		fmt.Printf("❌ Handler Error: %v\n", err)
+		utils.WriteError(w, http.StatusInternalServerError, message, err)
+	}
+}
+
+// LogWatch adalah handler untuk endpoint POST /api/history
+// Body: { "title_id": "...", "watched_on": "YYYY-MM-DD", "is_rewatch": true, "review_id": 1, "note": "..." }
+// Semua field kecuali title_id optional (watched_on default hari ini, is_rewatch otomatis)
+func (h *WatchHistoryHandler) LogWatch(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get user dari context (di-set oleh Auth middleware)
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 3. Parse request body
+	var req models.WatchHistoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	// 4. Call service
+	entry, err := h.historyService.LogWatch(user.UserID, req)
+	if err != nil {
+		writeHistoryError(w, err, "Failed to log watch")
+		return
+	}
+
+	// 5. Return response
+	utils.WriteSuccess(w, "Watch logged successfully", entry)
+}
+
+// DeleteWatch adalah handler untuk endpoint DELETE /api/history/{id}
+func (h *WatchHistoryHandler) DeleteWatch(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get user dari context
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 3. Get watch ID dari URL path
+	watchID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid watch ID format", err)
+		return
+	}
+
+	// 4. Call service
+	if err := h.historyService.DeleteWatch(user.UserID, watchID); err != nil {
+		writeHistoryError(w, err, "Failed to delete watch entry")
+		return
+	}
+
+	// 5. Return response
+	utils.WriteSuccess(w, "Watch entry deleted successfully", nil)
+}
+
+// GetHistory adalah handler untuk endpoint GET /api/history
+// Query param: from & to ("YYYY-MM-DD", inclusive, optional), page (default 1), limit (default 20, max 100)
+// Return: WatchHistoryResponse (terbaru dulu + pagination info)
+func (h *WatchHistoryHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get user dari context
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 3. Call service
+	from := r.URL.Query().Get("from")
+	to := r.URL.Query().Get("to")
+	page, limit := parsePagination(r, 20, 100)
+	entries, total, err := h.historyService.GetHistory(user.UserID, from, to, page, limit)
+	if err != nil {
+		writeHistoryError(w, err, "Failed to fetch watch history")
+		return
+	}
+
+	// 4. Return response
+	utils.WriteSuccess(w, "Watch history retrieved successfully", models.WatchHistoryResponse{
+		From:       from,
+		To:         to,
+		Entries:    entries,
+		Pagination: newPaginationInfo(page, limit, total),
+	})
+}
+
+// GetSeriesProgress adalah handler untuk endpoint GET /api/history/series/{id}/progress
+// Return: episode yang sudah ditonton per season + next episode
+func (h *WatchHistoryHandler) GetSeriesProgress(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get user dari context
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 3. Call service
+	progress, err := h.historyService.GetSeriesProgress(user.UserID, mux.Vars(r)["id"])
+	if err != nil {
+		writeHistoryError(w, err, "Failed to fetch series progress")
+		return
+	}
+
+	// 4. Return response
+	utils.WriteSuccess(w, "Series progress retrieved successfully", progress)
+}
+
+// GetYearInReview adalah handler untuk endpoint GET /api/history/stats dan /api/history/stats/{year}
+// Tanpa {year} = tahun berjalan
+// Return: total tontonan, jam menonton (dari runtimeMinutes), per bulan, dan top genres
+func (h *WatchHistoryHandler) GetYearInReview(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Get user dari context
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 3. Parse year dari URL path (0 = tahun berjalan)
+	year := 0
+	if yearStr, ok := mux.Vars(r)["year"]; ok {
+		parsed, err := strconv.Atoi(yearStr)
+		if err != nil {
+			utils.WriteError(w, http.StatusBadRequest, "Invalid year format", err)
+			return
+		}
+		year = parsed
+	}
+
+	// 4. Call service
+	stats, err := h.historyService.GetYearInReview(user.UserID, year)
+	if err != nil {
+		writeHistoryError(w, err, "Failed to fetch year in review")
+		return
+	}
+
+	// 5. Return response
+	utils.WriteSuccess(w, "Year in review retrieved successfully", stats)
+}
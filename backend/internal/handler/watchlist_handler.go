@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"film-dashboard-api/internal/middleware"
+	"film-dashboard-api/internal/models"
+	"film-dashboard-api/internal/repository"
+	"film-dashboard-api/internal/service"
+	"film-dashboard-api/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// WatchlistHandler adalah struct yang berisi semua handler untuk watchlist operations
+// Semua endpoint protected (butuh JWT token)
+type WatchlistHandler struct {
+	watchlistService *service.WatchlistService
+}
+
+// NewWatchlistHandler adalah constructor untuk bikin instance WatchlistHandler
+func NewWatchlistHandler(watchlistService *service.WatchlistService) *WatchlistHandler {
+	return &WatchlistHandler{
+		watchlistService: watchlistService,
+	}
+}
+
+// AddToWatchlist adalah handler untuk endpoint POST /api/watchlist
+// Body: { "title_id": "..." }
+// Idempotent - title yang sudah ada di watchlist tidak di-insert ulang
+func (h *WatchlistHandler) AddToWatchlist(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Pastikan method POST
+	if r.Method != http.MethodPost {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed", nil)
+		return
+	}
+
+	// 3. Get user dari context (di-set oleh Auth middleware)
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 4. Parse request body
+	var req models.WatchlistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	// 5. Call service untuk add ke watchlist
+	status, err := h.watchlistService.AddToWatchlist(user.UserID, req)
+	if err != nil {
+		if errors.Is(err, repository.ErrTitleNotFound) {
+			utils.WriteError(w, http.StatusNotFound, "Title not found", err)
+		} else if req.TitleID == "" {
+			utils.WriteError(w, http.StatusBadRequest, err.Error(), err)
+		} else {
+			fmt.Printf("❌ Handler Error: %v\n", err)
+			utils.WriteError(w, http.StatusInternalServerError, "Failed to add to watchlist", err)
+		}
+		return
+	}
+
+	// 6. Return success response
+	utils.WriteSuccess(w, "Title added to watchlist", status)
+}
+
+// RemoveFromWatchlist adalah handler untuk endpoint DELETE /api/watchlist/{title}
+// Idempotent - title yang tidak ada di watchlist tetap return success
+func (h *WatchlistHandler) RemoveFromWatchlist(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Pastikan method DELETE
+	if r.Method != http.MethodDelete {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed", nil)
+		return
+	}
+
+	// 3. Get user dari context
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 4. Get title ID dari URL path
+	titleID := mux.Vars(r)["title"]
+	if titleID == "" {
+		utils.WriteError(w, http.StatusBadRequest, "Title ID is required", nil)
+		return
+	}
+
+	// 5. Call service untuk remove dari watchlist
+	status, err := h.watchlistService.RemoveFromWatchlist(user.UserID, titleID)
+	if err != nil {
+		fmt.Printf("❌ Handler Error: %v\n", err)
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to remove from watchlist", err)
+		return
+	}
+
+	// 6. Return success response
+	utils.WriteSuccess(w, "Title removed from watchlist", status)
+}
+
+// GetWatchlistStatus adalah handler untuk endpoint GET /api/watchlist/status/{title}
+// Return: { title_id, in_watchlist, added_at }
+func (h *WatchlistHandler) GetWatchlistStatus(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Pastikan method GET
+	if r.Method != http.MethodGet {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed", nil)
+		return
+	}
+
+	// 3. Get user dari context
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 4. Get title ID dari URL path
+	titleID := mux.Vars(r)["title"]
+	if titleID == "" {
+		utils.WriteError(w, http.StatusBadRequest, "Title ID is required", nil)
+		return
+	}
+
+	// 5. Call service
+	status, err := h.watchlistService.GetWatchlistStatus(user.UserID, titleID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch watchlist status", err)
+		return
+	}
+
+	// 6. Return response
+	utils.WriteSuccess(w, "Watchlist status retrieved successfully", status)
+}
+
+// GetWatchlist adalah handler untuk endpoint GET /api/watchlist
+// Query param: page (default 1), limit (default 20, max 100)
+// Return: WatchlistResponse (items terbaru dulu + pagination info)
+func (h *WatchlistHandler) GetWatchlist(w http.ResponseWriter, r *http.Request) {
+	// 1. Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 2. Pastikan method GET
+	if r.Method != http.MethodGet {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed", nil)
+		return
+	}
+
+	// 3. Get user dari context
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, "User not found", nil)
+		return
+	}
+
+	// 4. Call service
+	page, limit := parsePagination(r, 20, 100)
+	items, total, err := h.watchlistService.GetWatchlist(user.UserID, page, limit)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch watchlist", err)
+		return
+	}
+
+	// 5. Return response
+	utils.WriteSuccess(w, "Watchlist retrieved successfully", models.WatchlistResponse{
+		Items:      items,
+		Pagination: newPaginationInfo(page, limit, total),
+	})
+}
@@ -25,23 +25,8 @@ const UserContextKey contextKey = "user"
 func Auth(authService *service.AuthService) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// 1. Try to get token dari httpOnly cookie terlebih dahulu
-			token := ""
-			if cookie, err := r.Cookie("auth_token"); err == nil {
-				token = cookie.Value
-			}
-
-			// 2. Fallback: get dari Authorization header (untuk backward compatibility)
-			if token == "" {
-				authHeader := r.Header.Get("Authorization")
-				if authHeader != "" {
-					// Format: "Bearer <token>"
-					parts := strings.Split(authHeader, " ")
-					if len(parts) == 2 && parts[0] == "Bearer" {
-						token = parts[1]
-					}
-				}
-			}
+			// 1-2. Get token dari httpOnly cookie / Authorization header
+			token := tokenFromRequest(r)
 
 			// 3. Check apakah token ada
 			if token == "" {
@@ -67,6 +52,40 @@ func Auth(authService *service.AuthService) func(http.Handler) http.Handler {
 	}
 }
 
+// OptionalAuth sama seperti Auth, tapi request tanpa token (atau token invalid) tetap diteruskan
+// tanpa user di context. Dipakai untuk public routes yang menampilkan data tambahan
+// kalau user login (contoh: private list milik user sendiri)
+func OptionalAuth(authService *service.AuthService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token := tokenFromRequest(r); token != "" {
+				if user, err := authService.ValidateToken(token); err == nil {
+					r = r.WithContext(context.WithValue(r.Context(), UserContextKey, user))
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// tokenFromRequest mengambil JWT dari httpOnly cookie "auth_token",
+// fallback ke Authorization header ("Bearer <token>") untuk backward compatibility
+func tokenFromRequest(r *http.Request) string {
+	if cookie, err := r.Cookie("auth_token"); err == nil && cookie.Value != "" {
+		return cookie.Value
+	}
+
+	authHeader := r.Header.Get("Authorization")
+	if authHeader != "" {
+		// Format: "Bearer <token>"
+		parts := strings.Split(authHeader, " ")
+		if len(parts) == 2 && parts[0] == "Bearer" {
+			return parts[1]
+		}
+	}
+	return ""
+}
+
 // GetUserFromContext adalah helper function untuk extract user dari context
 // Digunakan di handler untuk get user yang sedang login
 func GetUserFromContext(ctx context.Context) (*models.User, bool) {
@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// trustedProxies adalah proxy / load balancer yang boleh mengisi X-Forwarded-For & X-Real-IP
+// Kosong = header tersebut diabaikan, client IP selalu dari RemoteAddr
+var trustedProxies []*net.IPNet
+
+// SetTrustedProxies mengatur proxy yang dipercaya (IP atau CIDR, contoh: 10.0.0.1, 10.0.0.0/8)
+// Dipanggil sekali saat startup sebelum server menerima request
+func SetTrustedProxies(proxies []string) error {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return fmt.Errorf("invalid trusted proxy: %q", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return fmt.Errorf("invalid trusted proxy: %q", proxy)
+		}
+		nets = append(nets, ipNet)
+	}
+	trustedProxies = nets
+	return nil
+}
+
+// ClientIP extract client IP dari request (dipakai oleh rate limiter dan visitor key analytics)
+// X-Forwarded-For / X-Real-IP hanya dipakai kalau request datang dari trusted proxy.
+// X-Forwarded-For dibaca dari kanan: hop pertama yang bukan trusted proxy adalah client,
+// jadi nilai palsu yang dikirim client di sebelah kiri chain tidak berpengaruh
+func ClientIP(r *http.Request) string {
+	remote, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remote = r.RemoteAddr
+	}
+	if !isTrustedProxy(remote) {
+		return remote
+	}
+
+	// Check X-Forwarded-For header (bisa lebih dari satu header, masing-masing comma-separated)
+	var hops []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		for _, hop := range strings.Split(header, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	client := remote
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := parseHop(hops[i])
+		if ip == "" {
+			break
+		}
+		client = ip
+		if !isTrustedProxy(ip) {
+			return ip
+		}
+	}
+	if len(hops) > 0 {
+		return client
+	}
+
+	// Check X-Real-IP header
+	if ip := parseHop(r.Header.Get("X-Real-IP")); ip != "" {
+		return ip
+	}
+
+	return remote
+}
+
+// parseHop return IP yang sudah dinormalisasi dari satu hop (boleh dengan port), "" kalau tidak valid
+func parseHop(hop string) string {
+	hop = strings.TrimSpace(hop)
+	if host, _, err := net.SplitHostPort(hop); err == nil {
+		hop = host
+	}
+	ip := net.ParseIP(hop)
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
+
+// isTrustedProxy return true kalau ip termasuk trustedProxies
+func isTrustedProxy(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, proxy := range trustedProxies {
+		if proxy.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
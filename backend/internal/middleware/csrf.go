@@ -36,12 +36,17 @@ func GenerateCSRFToken() (string, error) {
 func CSRFProtection() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Exempt auth endpoints, titles endpoints, dan reviews endpoints dari CSRF
+			// Exempt auth, titles, reviews, watchlist, lists, history, moderation, dan analytics endpoints dari CSRF
 			// (protected by JWT authentication & rate limiting & SameSite)
 			path := r.URL.Path
 			if strings.HasPrefix(path, "/api/auth/") || 
 			   strings.HasPrefix(path, "/api/titles/") ||
-			   strings.HasPrefix(path, "/api/reviews") {
+			   strings.HasPrefix(path, "/api/reviews") ||
+			   strings.HasPrefix(path, "/api/watchlist") ||
+			   strings.HasPrefix(path, "/api/lists") ||
+			   strings.HasPrefix(path, "/api/history") ||
+			   strings.HasPrefix(path, "/api/admin/moderation") ||
+			   strings.HasPrefix(path, "/api/analytics") {
 				next.ServeHTTP(w, r)
 				return
 			}
@@ -1,7 +1,6 @@
 package middleware
 
 import (
-	"net"
 	"net/http"
 	"sync"
 	"time"
@@ -93,8 +92,8 @@ func (rl *RateLimiter) cleanup() {
 func RateLimitMiddleware(limiter *RateLimiter) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Get client IP
-			ip := getClientIP(r)
+			// Get client IP (header proxy hanya dipercaya dari TRUSTED_PROXIES, lihat ClientIP)
+			ip := ClientIP(r)
 
 			if !limiter.Allow(ip) {
 				utils.WriteError(w, http.StatusTooManyRequests, "Too many requests. Please try again later.", nil)
@@ -105,26 +104,3 @@ func RateLimitMiddleware(limiter *RateLimiter) func(http.Handler) http.Handler {
 		})
 	}
 }
-
-// getClientIP extract client IP dari request
-func getClientIP(r *http.Request) string {
-	// Check X-Forwarded-For header (untuk proxy/load balancer)
-	ip := r.Header.Get("X-Forwarded-For")
-	if ip != "" {
-		return ip
-	}
-
-	// Check X-Real-IP header
-	ip = r.Header.Get("X-Real-IP")
-	if ip != "" {
-		return ip
-	}
-
-	// Get dari RemoteAddr
-	ip, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		return r.RemoteAddr
-	}
-
-	return ip
-}
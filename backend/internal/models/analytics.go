@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// TitleViewEvent merepresentasikan satu detail view yang menunggu di-flush ke TitleViews
+type TitleViewEvent struct {
+	TitleID    string
+	UserID     *int   // nil = tanpa login
+	VisitorKey string // "u:<user_id>" atau "a:<hash>" untuk unique viewers
+	ViewedAt   time.Time
+}
+
+// DailyTitleStats merepresentasikan statistik satu title di satu hari
+type DailyTitleStats struct {
+	Date          string `json:"date"` // YYYY-MM-DD
+	Views         int    `json:"views"`
+	UniqueViewers int    `json:"unique_viewers"`
+	Reviews       int    `json:"reviews"`
+}
+
+// TitleAnalytics - Response untuk GET /api/analytics/titles/{id}
+// ReviewConversion = ConvertedViewers / LoggedInViewers (nil kalau belum ada viewer yang login)
+// ConvertedViewers = viewer login yang menulis review title ini setelah view pertamanya di periode
+type TitleAnalytics struct {
+	TitleID          string             `json:"title_id"`
+	From             string             `json:"from"` // YYYY-MM-DD (inclusive)
+	To               string             `json:"to"`   // YYYY-MM-DD (inclusive, hari ini)
+	Days             int                `json:"days"`
+	TotalViews       int                `json:"total_views"`
+	UniqueViewers    int                `json:"unique_viewers"`
+	LoggedInViewers  int                `json:"logged_in_viewers"`
+	Reviews          int                `json:"reviews"`
+	ConvertedViewers int                `json:"converted_viewers"`
+	ReviewConversion *float64           `json:"review_conversion"`
+	Daily            []*DailyTitleStats `json:"daily"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// AutocompleteSuggestion merepresentasikan satu suggestion typeahead (title atau person)
+// Weight hanya dipakai untuk ranking di index (vote_count untuk title, jumlah credits untuk person)
+type AutocompleteSuggestion struct {
+	Kind         string  `json:"kind"` // "title" atau "person"
+	ID           string  `json:"id"`
+	Name         string  `json:"name"`
+	OriginalName *string `json:"original_name,omitempty"` // title saja, kalau beda dengan name
+	StartYear    *int    `json:"start_year,omitempty"`    // title saja
+	Type         *string `json:"type,omitempty"`          // title saja (movie, tvSeries, ...)
+	Weight       int     `json:"-"`
+}
+
+// AutocompleteResponse merepresentasikan response untuk GET /api/autocomplete
+type AutocompleteResponse struct {
+	Query     string                    `json:"query"`
+	Titles    []*AutocompleteSuggestion `json:"titles"`
+	Persons   []*AutocompleteSuggestion `json:"persons"`
+	IndexedAt time.Time                 `json:"indexed_at"` // waktu terakhir index di-refresh dari database
+}
@@ -0,0 +1,31 @@
+package models
+
+// Season merepresentasikan ringkasan satu season dari sebuah series (dari table episodes)
+type Season struct {
+	SeasonNumber  *int     `json:"season_number"`
+	EpisodeCount  int      `json:"episode_count"`
+	FirstAirDate  *string  `json:"first_air_date"`
+	LastAirDate   *string  `json:"last_air_date"`
+	AverageRating *float64 `json:"average_rating"`
+}
+
+// Episode merepresentasikan satu episode di dalam season
+// TitleID adalah title_id episode itu sendiri (bisa dibuka via /api/titles/{id}/detail)
+type Episode struct {
+	TitleID        string   `json:"title_id"`
+	Name           *string  `json:"name"`
+	SeasonNumber   *int     `json:"season_number"`
+	EpisodeNumber  *int     `json:"episode_number"`
+	AirDate        *string  `json:"air_date"`
+	VoteAverage    *float64 `json:"vote_average"`
+	VoteCount      *int     `json:"vote_count"`
+	RuntimeMinutes *int     `json:"runtime_minutes"`
+}
+
+// EpisodeParent menghubungkan episode ke parent series-nya
+type EpisodeParent struct {
+	ParentTitleID string  `json:"parent_title_id"`
+	ParentName    *string `json:"parent_name"`
+	SeasonNumber  *int    `json:"season_number"`
+	EpisodeNumber *int    `json:"episode_number"`
+}
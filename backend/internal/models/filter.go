@@ -1,18 +1,65 @@
 package models
 
+import (
+	"errors"
+	"strings"
+)
+
 // FilterRequest merepresentasikan request body untuk filter titles
 // Semua parameter bersifat optional
 // Supports multiple selections untuk Genre, Type, Status, dan Countries
+// Range filters (yearFrom/yearTo, ratingMin/ratingMax, runtimeMin/runtimeMax) inclusive di kedua sisi
+// Q (keyword) membatasi hasil ke titles yang match full-text search - dipakai juga oleh GET /api/titles/search
 type FilterRequest struct {
+	Q                     string   `json:"q"`                     // Optional: search keyword (name, original_name, alternate titles)
 	GenreIDs              []string `json:"genreIds"`              // Optional: multiple genre IDs
 	TypeIDs               []string `json:"typeIds"`               // Optional: multiple type IDs
 	StatusIDs             []string `json:"statusIds"`             // Optional: multiple status IDs
 	OriginCountryIDs      []string `json:"originCountryIds"`      // Optional: multiple origin country IDs
 	ProductionCountryIDs  []string `json:"productionCountryIds"`  // Optional: multiple production country IDs
 	Year                  *int     `json:"year"`                  // Optional: release year
-	SortBy                string   `json:"sortBy"`                // Default: "released" (rating, popularity, etc)
+	YearFrom              *int     `json:"yearFrom"`              // Optional: startYear >= yearFrom
+	YearTo                *int     `json:"yearTo"`                // Optional: startYear <= yearTo
+	RatingMin             *float64 `json:"ratingMin"`             // Optional: vote_average >= ratingMin
+	RatingMax             *float64 `json:"ratingMax"`             // Optional: vote_average <= ratingMax
+	RuntimeMin            *int     `json:"runtimeMin"`            // Optional: runtimeMinutes >= runtimeMin
+	RuntimeMax            *int     `json:"runtimeMax"`            // Optional: runtimeMinutes <= runtimeMax
+	MinVotes              *int     `json:"minVotes"`              // Optional: vote_count >= minVotes
+	Adult                 *bool    `json:"adult"`                 // Optional: true/false, nil = semua
+	InProduction          *bool    `json:"inProduction"`          // Optional: true/false, nil = semua
+	SortBy                string   `json:"sortBy"`                // Default: "released" (rating, popularity, community, relevance (butuh q), etc)
 	Page                  int      `json:"page"`                  // Pagination: page number (default 1)
 	Limit                 int      `json:"limit"`                 // Pagination: items per page (default 20)
+	Cursor                string   `json:"cursor"`                // Optional: opaque keyset cursor (nextCursor dari response sebelumnya)
+	IncludeFacets         bool     `json:"includeFacets"`         // Optional: hitung facet counts (default false, dikirim oleh filter sidebar)
+}
+
+// Validate mengecek range filters (from <= to, rating 0-10, nilai tidak negatif)
+// dan sortBy relevance yang hanya valid kalau ada keyword
+func (f *FilterRequest) Validate() error {
+	if f.YearFrom != nil && f.YearTo != nil && *f.YearFrom > *f.YearTo {
+		return errors.New("yearFrom must be less than or equal to yearTo")
+	}
+	if (f.RatingMin != nil && (*f.RatingMin < 0 || *f.RatingMin > 10)) ||
+		(f.RatingMax != nil && (*f.RatingMax < 0 || *f.RatingMax > 10)) {
+		return errors.New("ratingMin and ratingMax must be between 0 and 10")
+	}
+	if f.RatingMin != nil && f.RatingMax != nil && *f.RatingMin > *f.RatingMax {
+		return errors.New("ratingMin must be less than or equal to ratingMax")
+	}
+	if (f.RuntimeMin != nil && *f.RuntimeMin < 0) || (f.RuntimeMax != nil && *f.RuntimeMax < 0) {
+		return errors.New("runtimeMin and runtimeMax must not be negative")
+	}
+	if f.RuntimeMin != nil && f.RuntimeMax != nil && *f.RuntimeMin > *f.RuntimeMax {
+		return errors.New("runtimeMin must be less than or equal to runtimeMax")
+	}
+	if f.MinVotes != nil && *f.MinVotes < 0 {
+		return errors.New("minVotes must not be negative")
+	}
+	if f.SortBy == "relevance" && strings.TrimSpace(f.Q) == "" {
+		return errors.New("sortBy relevance requires a search keyword (q)")
+	}
+	return nil
 }
 
 // FilteredTitle merepresentasikan hasil filter titles (dari sp_filter_titles)
@@ -39,15 +86,21 @@ type FilteredTitle struct {
 
 // FilterResponse merepresentasikan response structure untuk filter results
 type FilterResponse struct {
-	Success bool             `json:"success"` // true/false
-	Data    []*FilmCardData  `json:"data"`    // array of filtered titles (using consistent FilmCardData)
-	Count   int              `json:"count"`   // total count from database
+	Success    bool             `json:"success"`    // true/false
+	Data       []*FilmCardData  `json:"data"`       // array of filtered titles (using consistent FilmCardData)
+	Count      int              `json:"count"`      // total count from database (exact)
+	Pagination *PaginationInfo  `json:"pagination"` // pagination block (page, total, cursor)
+	Facets     *FilterFacets    `json:"facets,omitempty"` // per-facet counts (hanya kalau includeFacets, tidak untuk cursor page)
 }
 
 // PaginationInfo merepresentasikan informasi pagination
+// NextCursor bisa dikirim balik sebagai FilterRequest.Cursor untuk keyset pagination
+// Page & TotalPage tidak dikirim untuk response keyset (cursor) karena tidak bermakna
 type PaginationInfo struct {
-	Page      int `json:"page"`
-	Limit     int `json:"limit"`
-	Total     int `json:"total"`
-	TotalPage int `json:"totalPage"`
+	Page       int    `json:"page,omitempty"`
+	Limit      int    `json:"limit"`
+	Total      int    `json:"total"`
+	TotalPage  int    `json:"totalPage,omitempty"`
+	HasNext    bool   `json:"hasNext"`
+	NextCursor string `json:"nextCursor,omitempty"`
 }
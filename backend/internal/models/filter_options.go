@@ -18,10 +18,71 @@ type StatusOption struct {
 	StatusName string `json:"status_name"`
 }
 
+// OriginCountryOption merepresentasikan origin country option dari database
+type OriginCountryOption struct {
+	OriginCountryTypeID string `json:"origin_country_type_id"`
+	OriginCountryName   string `json:"origin_country_name"`
+}
+
+// ProductionCountryOption merepresentasikan production country option dari database
+type ProductionCountryOption struct {
+	ProductionCountryTypeID string `json:"production_country_type_id"`
+	ProductionCountryName   string `json:"production_country_name"`
+}
+
+// SpokenLanguageOption merepresentasikan spoken language option dari database
+type SpokenLanguageOption struct {
+	SpokenLanguageTypeID string `json:"spoken_language_type_id"`
+	SpokenLanguageName   string `json:"spoken_language_name"`
+}
+
+// NetworkOption merepresentasikan network option dari database
+type NetworkOption struct {
+	NetworkTypeID string `json:"network_type_id"`
+	NetworkName   string `json:"network_name"`
+}
+
+// ProductionCompanyOption merepresentasikan production company option dari database
+type ProductionCompanyOption struct {
+	ProductionCompanyTypeID string `json:"production_company_type_id"`
+	ProductionCompanyName   string `json:"production_company_name"`
+}
+
 // FilterOptionsResponse merepresentasikan semua filter options
+// Years diambil dari MIN/MAX startYear yang benar-benar ada di titles (descending)
 type FilterOptionsResponse struct {
-	Genres   []*GenreOption   `json:"genres"`
-	Types    []*TypeOption    `json:"types"`
-	Statuses []*StatusOption  `json:"statuses"`
-	Years    []int            `json:"years"`
+	Genres              []*GenreOption             `json:"genres"`
+	Types               []*TypeOption              `json:"types"`
+	Statuses            []*StatusOption            `json:"statuses"`
+	OriginCountries     []*OriginCountryOption     `json:"origin_countries"`
+	ProductionCountries []*ProductionCountryOption `json:"production_countries"`
+	SpokenLanguages     []*SpokenLanguageOption    `json:"spoken_languages"`
+	Networks            []*NetworkOption           `json:"networks"`
+	ProductionCompanies []*ProductionCompanyOption `json:"production_companies"`
+	Years               []int                      `json:"years"`
+}
+
+// FacetCount merepresentasikan jumlah matching titles untuk satu option di sebuah facet
+// ID dan Name sama dengan option di FilterOptionsResponse, Count = 0 berarti dead end
+type FacetCount struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// DecadeCount merepresentasikan jumlah matching titles per dekade (contoh: 1990 = 1990-1999)
+type DecadeCount struct {
+	Decade int `json:"decade"`
+	Count  int `json:"count"`
+}
+
+// FilterFacets merepresentasikan per-facet counts untuk filter sidebar
+// Setiap facet dihitung dengan semua filter lain aktif, kecuali filter facet itu sendiri
+// (supaya option lain di facet yang sama tetap kelihatan jumlahnya)
+type FilterFacets struct {
+	Genres          []*FacetCount  `json:"genres"`
+	Types           []*FacetCount  `json:"types"`
+	Statuses        []*FacetCount  `json:"statuses"`
+	OriginCountries []*FacetCount  `json:"origin_countries"`
+	Decades         []*DecadeCount `json:"decades"`
 }
@@ -0,0 +1,88 @@
+package models
+
+import "time"
+
+// Report reasons (sama dengan CHECK constraint ReviewReports.reason)
+var ReportReasons = []string{"spam", "offensive", "harassment", "spoiler", "other"}
+
+// Report status
+const (
+	ReportStatusOpen      = "open"
+	ReportStatusResolved  = "resolved"
+	ReportStatusDismissed = "dismissed"
+)
+
+// Moderation actions (sama dengan CHECK constraint ModerationActions.action)
+const (
+	ModerationHideReview    = "hide_review"
+	ModerationRestoreReview = "restore_review"
+	ModerationDeleteReview  = "delete_review"
+	ModerationDismissReport = "dismiss_report"
+	ModerationWarnUser      = "warn_user"
+	ModerationSuspendUser   = "suspend_user"
+	ModerationReinstateUser = "reinstate_user"
+)
+
+// ReviewReportRequest - Request body untuk POST /api/reviews/{id}/report
+type ReviewReportRequest struct {
+	Reason  string  `json:"reason"`
+	Details *string `json:"details"`
+}
+
+// ReviewReport merepresentasikan satu laporan terhadap review
+type ReviewReport struct {
+	ReportID         int        `json:"report_id"`
+	ReviewID         int        `json:"review_id"`
+	ReporterID       int        `json:"reporter_id"`
+	ReporterUsername string     `json:"reporter_username"`
+	Reason           string     `json:"reason"`
+	Details          *string    `json:"details"`
+	Status           string     `json:"status"`
+	CreatedAt        time.Time  `json:"created_at"`
+	ResolvedAt       *time.Time `json:"resolved_at"`
+}
+
+// ReportedReview adalah review yang dilaporkan beserta konteks untuk moderator
+type ReportedReview struct {
+	ReviewID       int       `json:"review_id"`
+	TitleID        string    `json:"title_id"`
+	UserID         int       `json:"user_id"`
+	Username       string    `json:"username"`
+	AuthorIsActive bool      `json:"author_is_active"`
+	Rating         int       `json:"rating"`
+	ReviewText     string    `json:"review_text"`
+	IsHidden       bool      `json:"is_hidden"`
+	CreatedAt      time.Time `json:"created_at"`
+	OpenReports    int       `json:"open_reports"`
+	AuthorWarnings int       `json:"author_warnings"`
+}
+
+// ModerationReport adalah satu item di moderation queue (report + review yang dilaporkan)
+type ModerationReport struct {
+	*ReviewReport
+	Review *ReportedReview `json:"review"`
+}
+
+// ModerationQueueResponse - Response paged untuk GET /api/admin/moderation/reports
+type ModerationQueueResponse struct {
+	Status     string              `json:"status"`
+	Reports    []*ModerationReport `json:"reports"`
+	Pagination *PaginationInfo     `json:"pagination"`
+}
+
+// ModerationActionRequest - Request body untuk semua moderation actions (reason optional)
+type ModerationActionRequest struct {
+	Reason *string `json:"reason"`
+}
+
+// ModerationAction merepresentasikan satu tindakan moderator (audit trail)
+type ModerationAction struct {
+	ActionID     int       `json:"action_id"`
+	ModeratorID  int       `json:"moderator_id"`
+	Action       string    `json:"action"`
+	ReviewID     *int      `json:"review_id"`
+	ReportID     *int      `json:"report_id"`
+	TargetUserID *int      `json:"target_user_id"`
+	Reason       *string   `json:"reason"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,44 @@
+package models
+
+// Person merepresentasikan data utama person dari table persons
+type Person struct {
+	PersonID    string  `json:"person_id"`
+	PrimaryName *string `json:"primary_name"`
+	BirthYear   *int    `json:"birth_year"`
+	DeathYear   *int    `json:"death_year"`
+}
+
+// PersonCredit merepresentasikan satu credit person di sebuah title (dari title_principals)
+type PersonCredit struct {
+	TitleID     string   `json:"title_id"`
+	Name        *string  `json:"name"`
+	StartYear   *int     `json:"start_year"`
+	VoteAverage *float64 `json:"vote_average"`
+	Type        *string  `json:"type"`
+	Category    *string  `json:"category"`
+	Job         *string  `json:"job"`
+	Characters  *string  `json:"characters"`
+}
+
+// FilmographyGroup merepresentasikan credits person untuk satu category (actor, director, writer, ...)
+// Total adalah jumlah semua credits di category ini, Credits hanya berisi page pertama
+type FilmographyGroup struct {
+	Category string          `json:"category"`
+	Total    int             `json:"total"`
+	Credits  []*PersonCredit `json:"credits"`
+}
+
+// PersonDetailResponse merepresentasikan response untuk GET /api/persons/{id}
+type PersonDetailResponse struct {
+	Person      *Person             `json:"person"`
+	Professions []string            `json:"professions"`
+	KnownFor    []*FilmCardData     `json:"known_for"`
+	Filmography []*FilmographyGroup `json:"filmography"`
+}
+
+// FilmographyResponse merepresentasikan response paged untuk GET /api/persons/{id}/filmography
+type FilmographyResponse struct {
+	Category   string          `json:"category,omitempty"`
+	Credits    []*PersonCredit `json:"credits"`
+	Pagination *PaginationInfo `json:"pagination"`
+}
@@ -9,8 +9,10 @@ type Review struct {
 	TitleID   string    `json:"title_id"`
 	Rating    int       `json:"rating"`
 	ReviewText string   `json:"review_text"`
+	ContainsSpoilers bool `json:"contains_spoilers"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	EditedAt  *time.Time `json:"edited_at"`
 }
 
 // ReviewRequest - Request body untuk create/update review
@@ -18,6 +20,8 @@ type ReviewRequest struct {
 	TitleID    string `json:"title_id"`
 	Rating     int    `json:"rating"`
 	ReviewText string `json:"review_text"`
+	// ContainsSpoilers = author menandai review berisi spoiler
+	ContainsSpoilers bool `json:"contains_spoilers"`
 }
 
 // ReviewResponse - Safe response model untuk frontend
@@ -30,4 +34,133 @@ type ReviewResponse struct {
 	ReviewText string    `json:"review_text"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
+
+	// Helpfulness votes (MyVote = vote user yang sedang login, nil kalau belum vote / tidak login)
+	HelpfulCount    int     `json:"helpful_count"`
+	NotHelpfulCount int     `json:"not_helpful_count"`
+	MyVote          *string `json:"my_vote"`
+
+	// Jumlah comment yang belum dihapus (termasuk replies)
+	CommentCount int `json:"comment_count"`
+
+	// IsHidden = review di-hide moderator (tidak muncul di public listings, hanya terlihat oleh author)
+	IsHidden bool `json:"is_hidden"`
+
+	// Spoiler flag - kalau SpoilerRedacted true, review_text dikosongkan di listing
+	// (client bisa minta ?spoilers=true untuk menampilkan isi review)
+	ContainsSpoilers bool `json:"contains_spoilers"`
+	SpoilerRedacted  bool `json:"spoiler_redacted"`
+
+	// Edit marker - EditedAt = waktu edit terakhir (nil kalau belum pernah di-edit)
+	IsEdited bool       `json:"is_edited"`
+	EditedAt *time.Time `json:"edited_at"`
+}
+
+// ReviewRevision merepresentasikan satu versi lama sebuah review (sebelum di-edit)
+type ReviewRevision struct {
+	HistoryID        int       `json:"history_id"`
+	Rating           int       `json:"rating"`
+	ReviewText       string    `json:"review_text"`
+	ContainsSpoilers bool      `json:"contains_spoilers"`
+	WrittenAt        time.Time `json:"written_at"`
+	ReplacedAt       time.Time `json:"replaced_at"`
+}
+
+// ReviewHistoryResponse - Response untuk GET /api/reviews/{id}/history
+// Current = versi sekarang, Revisions = versi-versi sebelumnya (terbaru dulu)
+type ReviewHistoryResponse struct {
+	ReviewID  int               `json:"review_id"`
+	Current   *ReviewResponse   `json:"current"`
+	Revisions []*ReviewRevision `json:"revisions"`
+}
+
+// RatingBucket merepresentasikan jumlah review dengan rating tertentu (1-10)
+type RatingBucket struct {
+	Rating int `json:"rating"`
+	Count  int `json:"count"`
+}
+
+// RatingSummary merepresentasikan ringkasan community rating sebuah title (dihitung dari table Reviews)
+// Histogram selalu berisi 10 bucket (rating 1-10), Average nil kalau belum ada review
+type RatingSummary struct {
+	Average   *float64        `json:"average"`
+	Count     int             `json:"count"`
+	Histogram []*RatingBucket `json:"histogram"`
+}
+
+// ReviewListResponse - Response paged untuk GET /api/reviews/{title} dan /api/reviews/user
+// Summary hanya terisi untuk listing per title
+type ReviewListResponse struct {
+	Reviews    []ReviewResponse `json:"reviews"`
+	Sort       string           `json:"sort"`
+	Summary    *RatingSummary   `json:"summary,omitempty"`
+	Pagination *PaginationInfo  `json:"pagination"`
+}
+
+// Review vote values (dipakai di ReviewVoteRequest dan ReviewResponse.MyVote)
+const (
+	ReviewVoteHelpful    = "helpful"
+	ReviewVoteNotHelpful = "not_helpful"
+)
+
+// ReviewVoteRequest - Request body untuk PUT /api/reviews/{id}/vote
+// Vote: "helpful" atau "not_helpful"
+type ReviewVoteRequest struct {
+	Vote string `json:"vote"`
+}
+
+// ReviewVoteSummary merepresentasikan total vote sebuah review + vote user yang sedang login
+type ReviewVoteSummary struct {
+	ReviewID        int     `json:"review_id"`
+	HelpfulCount    int     `json:"helpful_count"`
+	NotHelpfulCount int     `json:"not_helpful_count"`
+	MyVote          *string `json:"my_vote"`
+}
+
+// ReviewCommentRequest - Request body untuk create/update comment
+// ParentCommentID diisi kalau comment adalah reply (diabaikan saat update)
+type ReviewCommentRequest struct {
+	Body            string `json:"body"`
+	ParentCommentID *int   `json:"parent_comment_id"`
+}
+
+// ReviewComment merepresentasikan satu comment di sebuah review
+// Comment yang sudah di-soft-delete tetap muncul (supaya thread utuh) dengan Body nil
+type ReviewComment struct {
+	CommentID       int       `json:"comment_id"`
+	ReviewID        int       `json:"review_id"`
+	ParentCommentID *int      `json:"parent_comment_id"`
+	UserID          int       `json:"user_id"`
+	Username        string    `json:"username"`
+	Body            *string   `json:"body"`
+	IsDeleted       bool      `json:"is_deleted"`
+	IsEdited        bool      `json:"is_edited"`
+	ReplyCount      int       `json:"reply_count"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// ReviewCommentListResponse - Response paged untuk GET /api/reviews/{id}/comments
+type ReviewCommentListResponse struct {
+	ReviewID        int              `json:"review_id"`
+	ParentCommentID *int             `json:"parent_comment_id"`
+	Comments        []*ReviewComment `json:"comments"`
+	Pagination      *PaginationInfo  `json:"pagination"`
+}
+
+// Orphan review reasons
+const (
+	OrphanReasonMissingTitle = "missing_title" // title_id tidak ada di table titles
+	OrphanReasonEpisode      = "episode"       // review untuk episode padahal review episode tidak diizinkan
+)
+
+// OrphanReview merepresentasikan review yang target title-nya tidak valid
+type OrphanReview struct {
+	ReviewID  int       `json:"review_id"`
+	UserID    int       `json:"user_id"`
+	Username  string    `json:"username"`
+	TitleID   string    `json:"title_id"`
+	Rating    int       `json:"rating"`
+	CreatedAt time.Time `json:"created_at"`
+	Reason    string    `json:"reason"`
 }
@@ -0,0 +1,25 @@
+package models
+
+import "fmt"
+
+// SearchQueryError dikembalikan kalau structured search query tidak valid
+// Position adalah index karakter (0-based) di query tempat error ditemukan
+type SearchQueryError struct {
+	Position int    `json:"position"`
+	Token    string `json:"token"`
+	Message  string `json:"message"`
+}
+
+// Error implement interface error
+func (e *SearchQueryError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Position)
+}
+
+// SearchQueryErrorResponse merepresentasikan 400 response untuk structured search query yang tidak valid
+type SearchQueryErrorResponse struct {
+	Success bool              `json:"success"` // Selalu false
+	Message string            `json:"message"`
+	Error   string            `json:"error"`
+	Query   string            `json:"query"`
+	Details *SearchQueryError `json:"details"` // posisi & token yang bermasalah
+}
@@ -13,6 +13,12 @@ type FilmCardData struct {
 	VoteAverage float64 `json:"vote_average"`
 	VoteCount   int     `json:"vote_count"`
 	GenreName   string  `json:"genre_name"`
+
+	// CommunityScore = Bayesian average imported votes + review lokal (nil kalau belum dihitung)
+	CommunityScore *float64 `json:"community_score"`
+
+	// TrendingSource hanya diisi oleh /api/titles/trending (lihat TrendingSourceActivity / TrendingSourcePopularity)
+	TrendingSource string `json:"trending_source,omitempty"`
 }
 
 // TrendingTitle - alias untuk backwards compatibility
@@ -54,3 +60,24 @@ func (a *Actor) ToResponse() ActorResponse {
 		PhotoURL:  a.PhotoURL,
 	}
 }
+
+// CommunityScorePrior adalah parameter Bayesian average untuk community score
+// Title dengan sedikit vote "ditarik" ke Mean sebanyak Weight virtual votes
+type CommunityScorePrior struct {
+	Mean   float64
+	Weight int
+}
+
+// Sumber satu title di response trending
+const (
+	TrendingSourceActivity   = "activity"   // dari TrendingScores (reviews, watchlist adds, detail views)
+	TrendingSourcePopularity = "popularity" // fallback sp_getTrendings (static popularity)
+)
+
+// TrendingWindow adalah time window untuk trending (?window=day|week|month)
+// Aktivitas lebih tua dari Hours tidak dihitung, bobotnya berkurang setengah setiap HalfLifeHours
+type TrendingWindow struct {
+	Name          string
+	Hours         int
+	HalfLifeHours float64
+}
@@ -17,6 +17,10 @@ type TitleDetail struct {
 	Type             *string  `json:"type"`
 	Status           *string  `json:"status"`
 	Tagline          *string  `json:"tagline"`
+
+	// Community score (Bayesian average imported votes + review lokal) & jumlah review lokal
+	CommunityScore       *float64 `json:"community_score"`
+	CommunityReviewCount *int     `json:"community_review_count"`
 }
 
 // Genre merepresentasikan genre dari title
@@ -54,12 +58,30 @@ type AirDate struct {
 
 // CastCrew merepresentasikan cast dan crew
 type CastCrew struct {
+	PersonID      *string `json:"person_id"` // untuk link ke GET /api/persons/{id}
 	Ordering      *int    `json:"ordering"`
 	PersonName    *string `json:"person_name"`
 	JobCategory   *string `json:"job_category"`
 	Characters    *string `json:"characters"`
 }
 
+// AlternateTitle merepresentasikan judul alternatif / lokal dari title (dari alternate_titles)
+type AlternateTitle struct {
+	Title           *string `json:"title"`
+	Region          *string `json:"region"`
+	Language        *string `json:"language"`
+	Types           *string `json:"types"`
+	Attributes      *string `json:"attributes"`
+	IsOriginalTitle *bool   `json:"is_original_title"`
+}
+
+// ExternalLink merepresentasikan link eksternal bertipe (homepage, IMDb, trailer, ...)
+type ExternalLink struct {
+	LinkTypeID *string `json:"link_type_id"`
+	LinkType   *string `json:"link_type"`
+	URL        *string `json:"url"`
+}
+
 // TitleDetailResponse merepresentasikan response lengkap untuk detail title
 type TitleDetailResponse struct {
 	Detail          *TitleDetail         `json:"detail"`
@@ -70,4 +92,9 @@ type TitleDetailResponse struct {
 	Networks        []*Network           `json:"networks"`
 	AirDates        []*AirDate           `json:"air_dates"`
 	CastAndCrew     []*CastCrew          `json:"cast_and_crew"`
+	AlternateTitles []*AlternateTitle    `json:"alternate_titles"`
+	Links           []*ExternalLink      `json:"links"`
+	Parent          *EpisodeParent       `json:"parent"`          // hanya terisi kalau title ini sebuah episode
+	Partial         bool                 `json:"partial"`         // true kalau ada section yang gagal di-load
+	FailedSections  []string             `json:"failed_sections"` // nama section yang gagal (contoh: "genres", "cast_and_crew")
 }
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// Visibility values untuk UserList
+const (
+	ListVisibilityPublic   = "public"   // tampil & bisa dibaca semua orang
+	ListVisibilityUnlisted = "unlisted" // bisa dibaca siapa saja yang punya slug
+	ListVisibilityPrivate  = "private"  // hanya owner
+)
+
+// UserList merepresentasikan satu list buatan user (tanpa entries)
+type UserList struct {
+	ListID      int       `json:"list_id"`
+	UserID      int       `json:"user_id"`
+	Username    string    `json:"username"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description"`
+	Slug        string    `json:"slug"`
+	Visibility  string    `json:"visibility"`
+	EntryCount  int       `json:"entry_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// UserListRequest - Request body untuk create/update list
+type UserListRequest struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+	Visibility  string  `json:"visibility"` // public, unlisted, private (default private)
+}
+
+// UserListEntry merepresentasikan satu title di list, dengan posisi dan note dari owner
+type UserListEntry struct {
+	Position int           `json:"position"`
+	Note     *string       `json:"note"`
+	AddedAt  time.Time     `json:"added_at"`
+	Title    *FilmCardData `json:"title"`
+}
+
+// UserListEntryRequest - Request body untuk add entry / update note
+type UserListEntryRequest struct {
+	TitleID string  `json:"title_id"`
+	Note    *string `json:"note"`
+}
+
+// UserListOrderRequest - Request body untuk reorder entries
+// TitleIDs harus berisi semua title di list, dalam urutan baru
+type UserListOrderRequest struct {
+	TitleIDs []string `json:"title_ids"`
+}
+
+// UserListDetailResponse merepresentasikan response untuk GET /api/lists/{slug}
+type UserListDetailResponse struct {
+	List       *UserList        `json:"list"`
+	Entries    []*UserListEntry `json:"entries"`
+	Pagination *PaginationInfo  `json:"pagination"`
+}
@@ -0,0 +1,82 @@
+package models
+
+// WatchHistoryRequest - Request body untuk log tontonan
+// WatchedOn format "YYYY-MM-DD" (default hari ini). IsRewatch nil = otomatis
+// (true kalau user sudah pernah log title ini sebelumnya)
+type WatchHistoryRequest struct {
+	TitleID   string  `json:"title_id"`
+	WatchedOn string  `json:"watched_on"`
+	IsRewatch *bool   `json:"is_rewatch"`
+	ReviewID  *int    `json:"review_id"`
+	Note      *string `json:"note"`
+}
+
+// WatchHistoryEntry merepresentasikan satu entry diary
+// Episode hanya terisi kalau title adalah episode dari sebuah series
+type WatchHistoryEntry struct {
+	WatchID        int            `json:"watch_id"`
+	TitleID        string         `json:"title_id"`
+	Name           *string        `json:"name"`
+	Type           *string        `json:"type"`
+	StartYear      *int           `json:"start_year"`
+	RuntimeMinutes *int           `json:"runtime_minutes"`
+	WatchedOn      string         `json:"watched_on"`
+	IsRewatch      bool           `json:"is_rewatch"`
+	ReviewID       *int           `json:"review_id"`
+	Note           *string        `json:"note"`
+	Episode        *EpisodeParent `json:"episode"`
+}
+
+// WatchHistoryResponse - Response paged untuk GET /api/history
+type WatchHistoryResponse struct {
+	From       string               `json:"from,omitempty"`
+	To         string               `json:"to,omitempty"`
+	Entries    []*WatchHistoryEntry `json:"entries"`
+	Pagination *PaginationInfo      `json:"pagination"`
+}
+
+// SeasonProgress merepresentasikan progress user di satu season
+type SeasonProgress struct {
+	SeasonNumber    *int `json:"season_number"`
+	TotalEpisodes   int  `json:"total_episodes"`
+	WatchedEpisodes int  `json:"watched_episodes"`
+}
+
+// SeriesProgress merepresentasikan episode-level progress user untuk sebuah series
+// NextEpisode = episode pertama (urut season, episode) yang belum ditonton, nil kalau sudah selesai
+type SeriesProgress struct {
+	SeriesID        string            `json:"series_id"`
+	TotalEpisodes   int               `json:"total_episodes"`
+	WatchedEpisodes int               `json:"watched_episodes"`
+	Percent         float64           `json:"percent"`
+	Seasons         []*SeasonProgress `json:"seasons"`
+	NextEpisode     *Episode          `json:"next_episode"`
+}
+
+// MonthStats merepresentasikan jumlah tontonan per bulan di year-in-review
+type MonthStats struct {
+	Month   int     `json:"month"` // 1-12
+	Entries int     `json:"entries"`
+	Hours   float64 `json:"hours"`
+}
+
+// GenreStats merepresentasikan jumlah tontonan per genre di year-in-review
+type GenreStats struct {
+	GenreName string `json:"genre_name"`
+	Entries   int    `json:"entries"`
+}
+
+// YearInReview merepresentasikan statistik tontonan user dalam satu tahun
+// Hours dihitung dari titles.runtimeMinutes (title tanpa runtime tidak dihitung)
+type YearInReview struct {
+	Year            int           `json:"year"`
+	Entries         int           `json:"entries"`
+	UniqueTitles    int           `json:"unique_titles"`
+	Rewatches       int           `json:"rewatches"`
+	EpisodesWatched int           `json:"episodes_watched"`
+	MinutesWatched  int           `json:"minutes_watched"`
+	HoursWatched    float64       `json:"hours_watched"`
+	ReviewsLinked   int           `json:"reviews_linked"`
+	ByMonth         []*MonthStats `json:"by_month"`
+	TopGenres       []*GenreStats `json:"top_genres"`
+}
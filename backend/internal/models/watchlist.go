@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// Watchlist - Full model (internal use), satu row di table Watchlist
+type Watchlist struct {
+	WatchlistID int       `json:"watchlist_id"`
+	UserID      int       `json:"user_id"`
+	TitleID     string    `json:"title_id"`
+	AddedAt     time.Time `json:"added_at"`
+}
+
+// WatchlistRequest - Request body untuk add title ke watchlist
+type WatchlistRequest struct {
+	TitleID string `json:"title_id"`
+}
+
+// WatchlistItem - satu entry watchlist beserta film card data title-nya
+type WatchlistItem struct {
+	WatchlistID int           `json:"watchlist_id"`
+	AddedAt     time.Time     `json:"added_at"`
+	Title       *FilmCardData `json:"title"`
+}
+
+// WatchlistStatus - Response untuk check apakah title ada di watchlist user
+type WatchlistStatus struct {
+	TitleID     string     `json:"title_id"`
+	InWatchlist bool       `json:"in_watchlist"`
+	AddedAt     *time.Time `json:"added_at"`
+}
+
+// WatchlistResponse - Response paged untuk GET /api/watchlist
+type WatchlistResponse struct {
+	Items      []*WatchlistItem `json:"items"`
+	Pagination *PaginationInfo  `json:"pagination"`
+}
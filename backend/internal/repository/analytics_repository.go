@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"film-dashboard-api/internal/models"
+)
+
+// MaxTitleViewBatch adalah jumlah row maksimal per INSERT di InsertTitleViews
+// 4 parameter per row, tetap di bawah batas 2100 parameter SQL Server
+const MaxTitleViewBatch = 500
+
+// AnalyticsRepository adalah struct yang berisi semua function untuk operasi database analytics
+// (TitleViews + Reviews per title)
+type AnalyticsRepository struct {
+	db *sql.DB
+}
+
+// NewAnalyticsRepository adalah constructor untuk bikin instance AnalyticsRepository
+func NewAnalyticsRepository(db *sql.DB) *AnalyticsRepository {
+	return &AnalyticsRepository{
+		db: db,
+	}
+}
+
+// InsertTitleViews menyimpan batch view events dalam satu multi-row INSERT per MaxTitleViewBatch row
+// viewed_at dihitung dari clock database (GETDATE() dikurangi umur event) supaya konsisten
+// dengan query analytics / trending walaupun timezone server API dan database berbeda
+func (r *AnalyticsRepository) InsertTitleViews(events []models.TitleViewEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	for start := 0; start < len(events); start += MaxTitleViewBatch {
+		end := start + MaxTitleViewBatch
+		if end > len(events) {
+			end = len(events)
+		}
+
+		values := make([]string, 0, end-start)
+		params := make([]interface{}, 0, (end-start)*4)
+		for _, event := range events[start:end] {
+			n := len(params)
+			values = append(values, fmt.Sprintf("(@p%d, @p%d, @p%d, DATEADD(MILLISECOND, -@p%d, GETDATE()))", n+1, n+2, n+3, n+4))
+			params = append(params, event.TitleID, event.UserID, event.VisitorKey, now.Sub(event.ViewedAt).Milliseconds())
+		}
+
+		query := "INSERT INTO TitleViews (title_id, user_id, visitor_key, viewed_at) VALUES " + strings.Join(values, ", ")
+		if _, err := r.db.ExecContext(ctx, query, params...); err != nil {
+			return fmt.Errorf("failed to insert title views: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetTitleAnalytics mengambil statistik satu title untuk `days` hari terakhir (termasuk hari ini)
+// Range tanggal dihitung dari clock database. Return ErrTitleNotFound kalau title_id tidak ada
+func (r *AnalyticsRepository) GetTitleAnalytics(titleID string, days int) (*models.TitleAnalytics, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// 1. Totals + range tanggal
+	// ConvertedViewers = viewer login yang menulis review setelah view pertamanya di periode
+	var exists bool
+	var from, to time.Time
+	result := &models.TitleAnalytics{TitleID: titleID, Days: days}
+	err := r.db.QueryRowContext(ctx, `
+		DECLARE @to DATE = CAST(GETDATE() AS DATE);
+		DECLARE @from DATE = DATEADD(DAY, 1 - @p2, @to);
+
+		SELECT
+			CAST(CASE WHEN EXISTS (SELECT 1 FROM titles WHERE title_id = @p1) THEN 1 ELSE 0 END AS BIT),
+			@from, @to,
+			(SELECT COUNT(*) FROM TitleViews
+				WHERE title_id = @p1 AND viewed_at >= @from),
+			(SELECT COUNT(DISTINCT visitor_key) FROM TitleViews
+				WHERE title_id = @p1 AND viewed_at >= @from),
+			(SELECT COUNT(DISTINCT user_id) FROM TitleViews
+				WHERE title_id = @p1 AND viewed_at >= @from),
+			(SELECT COUNT(*) FROM Reviews
+				WHERE title_id = @p1 AND created_at >= @from),
+			(SELECT COUNT(*) FROM (
+				SELECT user_id, MIN(viewed_at) AS first_view
+				FROM TitleViews
+				WHERE title_id = @p1 AND viewed_at >= @from AND user_id IS NOT NULL
+				GROUP BY user_id
+			) fv
+			WHERE EXISTS (SELECT 1 FROM Reviews rv
+				WHERE rv.title_id = @p1 AND rv.user_id = fv.user_id AND rv.created_at >= fv.first_view))`,
+		titleID, days,
+	).Scan(&exists, &from, &to, &result.TotalViews, &result.UniqueViewers,
+		&result.LoggedInViewers, &result.Reviews, &result.ConvertedViewers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get title analytics: %w", err)
+	}
+	if !exists {
+		return nil, ErrTitleNotFound
+	}
+	result.From = from.Format("2006-01-02")
+	result.To = to.Format("2006-01-02")
+	if result.LoggedInViewers > 0 {
+		conversion := float64(result.ConvertedViewers) / float64(result.LoggedInViewers)
+		result.ReviewConversion = &conversion
+	}
+
+	// 2. Daily breakdown - semua hari di range muncul (hari tanpa aktivitas = 0)
+	result.Daily = make([]*models.DailyTitleStats, 0, days)
+	byDate := make(map[string]*models.DailyTitleStats, days)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		stats := &models.DailyTitleStats{Date: day.Format("2006-01-02")}
+		result.Daily = append(result.Daily, stats)
+		byDate[stats.Date] = stats
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		DECLARE @from DATE = DATEADD(DAY, 1 - @p2, CAST(GETDATE() AS DATE));
+
+		SELECT COALESCE(v.day, rv.day), COALESCE(v.views, 0), COALESCE(v.unique_viewers, 0), COALESCE(rv.reviews, 0)
+		FROM (
+			SELECT CAST(viewed_at AS DATE) AS day, COUNT(*) AS views, COUNT(DISTINCT visitor_key) AS unique_viewers
+			FROM TitleViews
+			WHERE title_id = @p1 AND viewed_at >= @from
+			GROUP BY CAST(viewed_at AS DATE)
+		) v
+		FULL OUTER JOIN (
+			SELECT CAST(created_at AS DATE) AS day, COUNT(*) AS reviews
+			FROM Reviews
+			WHERE title_id = @p1 AND created_at >= @from
+			GROUP BY CAST(created_at AS DATE)
+		) rv ON rv.day = v.day`,
+		titleID, days,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily title analytics: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var day time.Time
+		var views, uniqueViewers, reviews int
+		if err := rows.Scan(&day, &views, &uniqueViewers, &reviews); err != nil {
+			return nil, fmt.Errorf("failed to scan daily title analytics: %w", err)
+		}
+		if stats, ok := byDate[day.Format("2006-01-02")]; ok {
+			stats.Views = views
+			stats.UniqueViewers = uniqueViewers
+			stats.Reviews = reviews
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate daily title analytics: %w", err)
+	}
+
+	return result, nil
+}
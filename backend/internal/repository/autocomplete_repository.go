@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"film-dashboard-api/internal/models"
+)
+
+// AutocompleteRepository mengambil semua nama yang di-index oleh autocomplete
+// (titles.name, titles.original_name, persons.primaryName)
+type AutocompleteRepository struct {
+	db *sql.DB
+}
+
+// NewAutocompleteRepository adalah constructor untuk bikin instance AutocompleteRepository
+func NewAutocompleteRepository(db *sql.DB) *AutocompleteRepository {
+	return &AutocompleteRepository{db: db}
+}
+
+// GetTitleSuggestions mengambil semua titles yang tampil di aplikasi (dbo.FilterTitles())
+// Weight = vote_count, supaya title populer muncul lebih dulu
+func (r *AutocompleteRepository) GetTitleSuggestions() ([]*models.AutocompleteSuggestion, error) {
+	query := `SELECT
+		t.title_id,
+		t.name,
+		NULLIF(t.original_name, t.name) AS original_name,
+		t.startYear,
+		ty.type_name,
+		COALESCE(t.vote_count, 0) AS weight
+	FROM titles t
+	JOIN dbo.FilterTitles() ft ON ft.title_id = t.title_id
+	LEFT JOIN types ty ON ty.type_id = t.type_id
+	WHERE t.name IS NOT NULL`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get title suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	suggestions := make([]*models.AutocompleteSuggestion, 0)
+	for rows.Next() {
+		s := &models.AutocompleteSuggestion{Kind: "title"}
+		if err := rows.Scan(&s.ID, &s.Name, &s.OriginalName, &s.StartYear, &s.Type, &s.Weight); err != nil {
+			return nil, fmt.Errorf("failed to scan title suggestion: %w", err)
+		}
+		suggestions = append(suggestions, s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating title suggestions: %w", err)
+	}
+
+	return suggestions, nil
+}
+
+// GetPersonSuggestions mengambil persons yang punya minimal satu credit di title_principals
+// Weight = jumlah credits, supaya person yang sering muncul tampil lebih dulu
+func (r *AutocompleteRepository) GetPersonSuggestions() ([]*models.AutocompleteSuggestion, error) {
+	query := `SELECT p.person_id, p.primaryName, c.credits
+	FROM persons p
+	JOIN (
+		SELECT person_id, COUNT(*) AS credits
+		FROM title_principals
+		GROUP BY person_id
+	) c ON c.person_id = p.person_id
+	WHERE p.primaryName IS NOT NULL`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get person suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	suggestions := make([]*models.AutocompleteSuggestion, 0)
+	for rows.Next() {
+		s := &models.AutocompleteSuggestion{Kind: "person"}
+		if err := rows.Scan(&s.ID, &s.Name, &s.Weight); err != nil {
+			return nil, fmt.Errorf("failed to scan person suggestion: %w", err)
+		}
+		suggestions = append(suggestions, s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating person suggestions: %w", err)
+	}
+
+	return suggestions, nil
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"film-dashboard-api/internal/models"
+)
+
+// refreshCommunityScoresQuery upsert TitleCommunityScores dari titles + Reviews
+// @p1 = prior mean, @p2 = prior weight, @p3 = title_id (NULL = semua titles)
+// Review yang di-hide moderator tidak dihitung
+const refreshCommunityScoresQuery = `
+	MERGE TitleCommunityScores WITH (HOLDLOCK) AS target
+	USING (
+		SELECT
+			t.title_id,
+			COALESCE(rs.review_count, 0) AS review_count,
+			COALESCE(rs.review_sum, 0) AS review_sum,
+			CAST(ROUND(
+				(COALESCE(t.vote_average, 0) * COALESCE(t.vote_count, 0) + COALESCE(rs.review_sum, 0) + @p1 * @p2)
+				/ NULLIF(COALESCE(t.vote_count, 0) + COALESCE(rs.review_count, 0) + @p2, 0)
+			, 2) AS DECIMAL(4,2)) AS community_score
+		FROM titles t
+		LEFT JOIN (
+			SELECT title_id, COUNT(*) AS review_count, SUM(rating) AS review_sum
+			FROM Reviews
+			WHERE is_hidden = 0 AND (@p3 IS NULL OR title_id = @p3)
+			GROUP BY title_id
+		) rs ON rs.title_id = t.title_id
+		WHERE @p3 IS NULL OR t.title_id = @p3
+	) AS source
+		ON target.title_id = source.title_id
+	WHEN MATCHED THEN
+		UPDATE SET review_count = source.review_count,
+			review_sum = source.review_sum,
+			community_score = source.community_score,
+			updated_at = GETDATE()
+	WHEN NOT MATCHED THEN
+		INSERT (title_id, review_count, review_sum, community_score)
+		VALUES (source.title_id, source.review_count, source.review_sum, source.community_score)
+	OPTION (RECOMPILE);
+`
+
+// GetCatalogMeanRating menghitung rata-rata vote catalog (weighted by vote_count)
+// Return nil kalau belum ada title dengan vote
+func (r *TitleRepository) GetCatalogMeanRating() (*float64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	var mean *float64
+	err := r.db.QueryRowContext(ctx, `SELECT
+		SUM(CAST(vote_average AS FLOAT) * vote_count) / NULLIF(SUM(CAST(vote_count AS FLOAT)), 0)
+	FROM titles
+	WHERE vote_count > 0 AND vote_average IS NOT NULL`).Scan(&mean)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get catalog mean rating: %w", err)
+	}
+	return mean, nil
+}
+
+// RefreshCommunityScore menghitung ulang community score satu title
+// Dipanggil setiap review title tersebut dibuat / diubah / dihapus / di-hide / di-restore
+func (r *TitleRepository) RefreshCommunityScore(titleID string, prior models.CommunityScorePrior) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(ctx, refreshCommunityScoresQuery, prior.Mean, prior.Weight, titleID); err != nil {
+		return fmt.Errorf("failed to refresh community score: %w", err)
+	}
+	return nil
+}
+
+// RefreshAllCommunityScores menghitung ulang community score semua titles (set-based, dipakai saat startup)
+// Return jumlah title yang di-upsert
+func (r *TitleRepository) RefreshAllCommunityScores(prior models.CommunityScorePrior) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, refreshCommunityScoresQuery, prior.Mean, prior.Weight, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to refresh community scores: %w", err)
+	}
+	affected, _ := result.RowsAffected()
+	return affected, nil
+}
+
+// fillCommunityScores mengisi CommunityScore untuk titles yang berasal dari stored procedure
+// (sp_getTrendings, sp_getTopRated) - satu query untuk semua titles
+func (r *TitleRepository) fillCommunityScores(ctx context.Context, titles []*models.FilmCardData) error {
+	if len(titles) == 0 {
+		return nil
+	}
+
+	byID := make(map[string][]*models.FilmCardData, len(titles))
+	placeholders := make([]string, 0, len(titles))
+	params := make([]interface{}, 0, len(titles))
+	for _, title := range titles {
+		if _, ok := byID[title.TitleID]; !ok {
+			params = append(params, title.TitleID)
+			placeholders = append(placeholders, fmt.Sprintf("@p%d", len(params)))
+		}
+		byID[title.TitleID] = append(byID[title.TitleID], title)
+	}
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`SELECT title_id, community_score
+	FROM TitleCommunityScores
+	WHERE title_id IN (%s)`, strings.Join(placeholders, ", ")), params...)
+	if err != nil {
+		return fmt.Errorf("failed to get community scores: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var titleID string
+		var score *float64
+		if err := rows.Scan(&titleID, &score); err != nil {
+			return fmt.Errorf("failed to scan community score: %w", err)
+		}
+		for _, title := range byID[titleID] {
+			title.CommunityScore = score
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,352 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"film-dashboard-api/internal/models"
+)
+
+var (
+	// ErrAlreadyReported dikembalikan kalau user sudah pernah melaporkan review yang sama
+	ErrAlreadyReported = errors.New("you have already reported this review")
+	// ErrReportNotFound dikembalikan kalau report_id tidak ada
+	ErrReportNotFound = errors.New("report not found")
+	// ErrUserNotFound dikembalikan kalau user_id tidak ada
+	ErrUserNotFound = errors.New("user not found")
+)
+
+// ModerationRepository adalah struct yang berisi semua function untuk operasi database moderation
+// (ReviewReports, ModerationActions, Reviews.is_hidden, Users.is_active)
+type ModerationRepository struct {
+	db *sql.DB
+}
+
+// NewModerationRepository adalah constructor untuk bikin instance ModerationRepository
+func NewModerationRepository(db *sql.DB) *ModerationRepository {
+	return &ModerationRepository{
+		db: db,
+	}
+}
+
+// rowQuerier adalah subset dari *sql.DB / *sql.Tx yang dipakai recordAction
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// recordAction menyimpan satu baris audit trail di ModerationActions dan return row tersebut
+func recordAction(ctx context.Context, q rowQuerier, action *models.ModerationAction) (*models.ModerationAction, error) {
+	err := q.QueryRowContext(ctx, `INSERT INTO ModerationActions (moderator_id, action, review_id, report_id, target_user_id, reason)
+		OUTPUT INSERTED.action_id, INSERTED.created_at
+		VALUES (@p1, @p2, @p3, @p4, @p5, @p6)`,
+		action.ModeratorID, action.Action, action.ReviewID, action.ReportID, action.TargetUserID, action.Reason,
+	).Scan(&action.ActionID, &action.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record moderation action: %w", err)
+	}
+	return action, nil
+}
+
+// CreateReport menyimpan laporan user terhadap review
+// Return ErrAlreadyReported kalau user sudah pernah melaporkan review ini
+func (r *ModerationRepository) CreateReport(reviewID int, reporterID int, reason string, details *string) (*models.ReviewReport, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	report := &models.ReviewReport{
+		ReviewID:   reviewID,
+		ReporterID: reporterID,
+		Reason:     reason,
+		Details:    details,
+		Status:     models.ReportStatusOpen,
+	}
+	err := r.db.QueryRowContext(ctx, `INSERT INTO ReviewReports (review_id, reporter_id, reason, details)
+		OUTPUT INSERTED.report_id, INSERTED.created_at
+		SELECT @p1, @p2, @p3, @p4
+		WHERE NOT EXISTS (SELECT 1 FROM ReviewReports WHERE review_id = @p1 AND reporter_id = @p2)`,
+		reviewID, reporterID, reason, details).Scan(&report.ReportID, &report.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrAlreadyReported
+		}
+		return nil, fmt.Errorf("failed to create report: %w", err)
+	}
+	return report, nil
+}
+
+// GetReports mengambil moderation queue secara paged (terlama dulu, supaya laporan lama ditangani duluan)
+// status "" = semua status
+// Return: reports untuk page yang diminta, total reports, dan error
+func (r *ModerationRepository) GetReports(status string, page int, limit int) ([]*models.ModerationReport, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	where := `
+		WHERE (@p1 = N'' OR rr.status = @p1)`
+
+	// 1. Total reports (untuk pagination)
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM ReviewReports rr`+where, status).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count reports: %w", err)
+	}
+
+	// 2. Reports untuk page ini beserta review & author
+	query := `SELECT
+			rr.report_id,
+			rr.review_id,
+			rr.reporter_id,
+			reporter.username,
+			rr.reason,
+			rr.details,
+			rr.status,
+			rr.created_at,
+			rr.resolved_at,
+			rv.title_id,
+			rv.user_id,
+			author.username,
+			author.is_active,
+			rv.rating,
+			rv.review_text,
+			rv.is_hidden,
+			rv.created_at,
+			(SELECT COUNT(*) FROM ReviewReports o WHERE o.review_id = rr.review_id AND o.status = 'open') AS open_reports,
+			(SELECT COUNT(*) FROM ModerationActions ma WHERE ma.target_user_id = rv.user_id AND ma.action = 'warn_user') AS author_warnings
+		FROM ReviewReports rr
+		INNER JOIN Users reporter ON reporter.user_id = rr.reporter_id
+		INNER JOIN Reviews rv ON rv.review_id = rr.review_id
+		INNER JOIN Users author ON author.user_id = rv.user_id` + where + `
+		ORDER BY rr.created_at ASC, rr.report_id ASC
+		OFFSET @p2 ROWS FETCH NEXT @p3 ROWS ONLY`
+
+	rows, err := r.db.QueryContext(ctx, query, status, (page-1)*limit, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get reports: %w", err)
+	}
+	defer rows.Close()
+
+	reports := make([]*models.ModerationReport, 0)
+	for rows.Next() {
+		report := &models.ModerationReport{
+			ReviewReport: &models.ReviewReport{},
+			Review:       &models.ReportedReview{},
+		}
+		err := rows.Scan(
+			&report.ReportID,
+			&report.ReviewID,
+			&report.ReporterID,
+			&report.ReporterUsername,
+			&report.Reason,
+			&report.Details,
+			&report.Status,
+			&report.CreatedAt,
+			&report.ResolvedAt,
+			&report.Review.TitleID,
+			&report.Review.UserID,
+			&report.Review.Username,
+			&report.Review.AuthorIsActive,
+			&report.Review.Rating,
+			&report.Review.ReviewText,
+			&report.Review.IsHidden,
+			&report.Review.CreatedAt,
+			&report.Review.OpenReports,
+			&report.Review.AuthorWarnings,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan report: %w", err)
+		}
+		report.Review.ReviewID = report.ReviewID
+		reports = append(reports, report)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating reports: %w", err)
+	}
+
+	return reports, total, nil
+}
+
+// SetReviewHidden hide (hidden = true) atau restore review dalam satu transaction:
+// update Reviews.is_hidden, tutup semua open reports untuk review tersebut
+// (hide = resolved, restore = dismissed), dan catat di ModerationActions
+// Return ErrReviewNotFound kalau review tidak ada
+func (r *ModerationRepository) SetReviewHidden(reviewID int, moderatorID int, hidden bool, reason *string) (*models.ModerationAction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var authorID int
+	err = tx.QueryRowContext(ctx, `UPDATE Reviews
+		SET is_hidden = @p2, hidden_at = CASE WHEN @p2 = 1 THEN GETDATE() ELSE NULL END
+		OUTPUT INSERTED.user_id
+		WHERE review_id = @p1`, reviewID, hidden).Scan(&authorID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to update review visibility: %w", err)
+	}
+
+	action := &models.ModerationAction{
+		ModeratorID:  moderatorID,
+		Action:       models.ModerationRestoreReview,
+		ReviewID:     &reviewID,
+		TargetUserID: &authorID,
+		Reason:       reason,
+	}
+	status := models.ReportStatusDismissed
+	if hidden {
+		action.Action = models.ModerationHideReview
+		status = models.ReportStatusResolved
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE ReviewReports
+		SET status = @p2, resolved_at = GETDATE(), resolved_by = @p3
+		WHERE review_id = @p1 AND status = 'open'`, reviewID, status, moderatorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to close reports: %w", err)
+	}
+
+	if _, err := recordAction(ctx, tx, action); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return action, nil
+}
+
+// DeleteReview menghapus review (reports, votes, dan comments ikut terhapus via cascade)
+// dan mencatatnya di ModerationActions. Return ErrReviewNotFound kalau review tidak ada
+func (r *ModerationRepository) DeleteReview(reviewID int, moderatorID int, reason *string) (*models.ModerationAction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var authorID int
+	err = tx.QueryRowContext(ctx, `DELETE FROM Reviews
+		OUTPUT DELETED.user_id
+		WHERE review_id = @p1`, reviewID).Scan(&authorID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to delete review: %w", err)
+	}
+
+	action, err := recordAction(ctx, tx, &models.ModerationAction{
+		ModeratorID:  moderatorID,
+		Action:       models.ModerationDeleteReview,
+		ReviewID:     &reviewID,
+		TargetUserID: &authorID,
+		Reason:       reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return action, nil
+}
+
+// DismissReport menolak satu laporan (review tidak diubah)
+// Return ErrReportNotFound kalau report tidak ada atau sudah tidak open
+func (r *ModerationRepository) DismissReport(reportID int, moderatorID int, reason *string) (*models.ModerationAction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var reviewID int
+	err = tx.QueryRowContext(ctx, `UPDATE ReviewReports
+		SET status = 'dismissed', resolved_at = GETDATE(), resolved_by = @p2
+		OUTPUT INSERTED.review_id
+		WHERE report_id = @p1 AND status = 'open'`, reportID, moderatorID).Scan(&reviewID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrReportNotFound
+		}
+		return nil, fmt.Errorf("failed to dismiss report: %w", err)
+	}
+
+	action, err := recordAction(ctx, tx, &models.ModerationAction{
+		ModeratorID: moderatorID,
+		Action:      models.ModerationDismissReport,
+		ReviewID:    &reviewID,
+		ReportID:    &reportID,
+		Reason:      reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return action, nil
+}
+
+// ModerateUser menjalankan action terhadap user (warn, suspend, reinstate) dan mencatatnya
+// suspend set Users.is_active = 0 (token user langsung ditolak oleh Auth middleware),
+// reinstate set is_active = 1, warn hanya dicatat di ModerationActions
+// Return ErrUserNotFound kalau user tidak ada
+func (r *ModerationRepository) ModerateUser(userID int, moderatorID int, action string, reason *string) (*models.ModerationAction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `SELECT user_id FROM Users WHERE user_id = @p1`
+	switch action {
+	case models.ModerationSuspendUser:
+		query = `UPDATE Users SET is_active = 0, updated_at = GETDATE() OUTPUT INSERTED.user_id WHERE user_id = @p1`
+	case models.ModerationReinstateUser:
+		query = `UPDATE Users SET is_active = 1, updated_at = GETDATE() OUTPUT INSERTED.user_id WHERE user_id = @p1`
+	}
+
+	var found int
+	if err := tx.QueryRowContext(ctx, query, userID).Scan(&found); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to moderate user: %w", err)
+	}
+
+	recorded, err := recordAction(ctx, tx, &models.ModerationAction{
+		ModeratorID:  moderatorID,
+		Action:       action,
+		TargetUserID: &userID,
+		Reason:       reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return recorded, nil
+}
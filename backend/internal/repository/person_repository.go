@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"film-dashboard-api/internal/models"
+)
+
+// PersonRepository adalah struct yang berisi semua function untuk operasi database Person
+// (persons, professions, known_for, title_principals)
+type PersonRepository struct {
+	db *sql.DB
+}
+
+// NewPersonRepository adalah constructor untuk bikin instance PersonRepository
+func NewPersonRepository(db *sql.DB) *PersonRepository {
+	return &PersonRepository{db: db}
+}
+
+// ErrPersonNotFound dikembalikan kalau person_id tidak ada di table persons
+var ErrPersonNotFound = errors.New("person not found")
+
+// personCreditSelect dan personCreditFrom dipakai bersama oleh semua query credits
+// Urutan kolom harus sama dengan urutan Scan ke PersonCredit
+const personCreditSelect = `tp.title_id,
+		t.name,
+		t.startYear,
+		t.vote_average,
+		ty.type_name,
+		COALESCE(tp.category, N'other') AS category,
+		tp.job,
+		tp.characters`
+
+const personCreditFrom = `FROM title_principals tp
+	JOIN titles t ON t.title_id = tp.title_id
+	LEFT JOIN types ty ON ty.type_id = t.type_id`
+
+// GetPersonDetail mengambil detail person: data utama, professions, known-for titles,
+// dan filmography yang di-group per category (setiap group berisi maksimal creditsPerGroup credits)
+// Return nil (tanpa error) kalau person tidak ditemukan
+func (r *PersonRepository) GetPersonDetail(personID string, creditsPerGroup int) (*models.PersonDetailResponse, error) {
+	response := &models.PersonDetailResponse{
+		Professions: make([]string, 0),
+		KnownFor:    make([]*models.FilmCardData, 0),
+		Filmography: make([]*models.FilmographyGroup, 0),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// 1. Get person
+	person := &models.Person{}
+	err := r.db.QueryRowContext(ctx,
+		`SELECT person_id, primaryName, birthYear, deathYear FROM persons WHERE person_id = @p1`, personID,
+	).Scan(&person.PersonID, &person.PrimaryName, &person.BirthYear, &person.DeathYear)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get person: %w", err)
+	}
+	response.Person = person
+
+	// 2. Get professions
+	rows, err := r.db.QueryContext(ctx, `SELECT pt.profession
+	FROM professions p
+	JOIN profession_type pt ON pt.profession_id = p.profession_id
+	WHERE p.person_id = @p1 AND pt.profession IS NOT NULL`, personID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get professions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var profession string
+		if err := rows.Scan(&profession); err != nil {
+			return nil, fmt.Errorf("failed to scan profession: %w", err)
+		}
+		response.Professions = append(response.Professions, profession)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating professions: %w", err)
+	}
+	rows.Close()
+
+	// 3. Get known-for titles (filmcard data, title yang tidak ada di titles otomatis ter-skip)
+	rows, err = r.db.QueryContext(ctx, `SELECT f.title_id, f.name, f.startYear, f.vote_average, f.vote_count, f.genre_name, cs.community_score
+	FROM known_for kf
+	CROSS APPLY dbo.fnGetFilmCardDetail(kf.title_id) f
+	LEFT JOIN TitleCommunityScores cs ON cs.title_id = f.title_id
+	WHERE kf.person_id = @p1
+	ORDER BY f.vote_count DESC`, personID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get known-for titles: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		title := &models.FilmCardData{}
+		err := rows.Scan(&title.TitleID, &title.Name, &title.StartYear, &title.VoteAverage, &title.VoteCount, &title.GenreName, &title.CommunityScore)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan known-for title: %w", err)
+		}
+		response.KnownFor = append(response.KnownFor, title)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating known-for titles: %w", err)
+	}
+	rows.Close()
+
+	// 4. Get filmography per category (top N per category + total per category)
+	query := fmt.Sprintf(`SELECT c.title_id, c.name, c.startYear, c.vote_average, c.type_name,
+		c.category, c.job, c.characters, c.category_total
+	FROM (
+		SELECT %s,
+			ROW_NUMBER() OVER (PARTITION BY COALESCE(tp.category, N'other') ORDER BY t.startYear DESC, tp.title_id) AS rn,
+			COUNT(*) OVER (PARTITION BY COALESCE(tp.category, N'other')) AS category_total
+		%s
+		WHERE tp.person_id = @p1
+	) c
+	WHERE c.rn <= @p2
+	ORDER BY c.category_total DESC, c.category, c.rn`, personCreditSelect, personCreditFrom)
+
+	rows, err = r.db.QueryContext(ctx, query, personID, creditsPerGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get filmography: %w", err)
+	}
+	defer rows.Close()
+
+	groups := make(map[string]*models.FilmographyGroup)
+	for rows.Next() {
+		credit := &models.PersonCredit{}
+		var total int
+		err := rows.Scan(
+			&credit.TitleID,
+			&credit.Name,
+			&credit.StartYear,
+			&credit.VoteAverage,
+			&credit.Type,
+			&credit.Category,
+			&credit.Job,
+			&credit.Characters,
+			&total,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan filmography credit: %w", err)
+		}
+
+		group, ok := groups[*credit.Category]
+		if !ok {
+			group = &models.FilmographyGroup{
+				Category: *credit.Category,
+				Total:    total,
+				Credits:  make([]*models.PersonCredit, 0),
+			}
+			groups[*credit.Category] = group
+			response.Filmography = append(response.Filmography, group)
+		}
+		group.Credits = append(group.Credits, credit)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating filmography: %w", err)
+	}
+
+	return response, nil
+}
+
+// GetFilmography mengambil credits person secara paged, optional difilter per category
+// Urutan: startYear DESC (title terbaru dulu)
+// Return: credits untuk page yang diminta, total credits, dan error
+// Return ErrPersonNotFound kalau person_id tidak ada (beda dengan person tanpa credits)
+func (r *PersonRepository) GetFilmography(personID string, category string, page int, limit int) ([]*models.PersonCredit, int, error) {
+	where := "WHERE tp.person_id = @p1"
+	params := []interface{}{personID}
+	if category != "" {
+		where += " AND COALESCE(tp.category, N'other') = @p2"
+		params = append(params, category)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// 1. Total credits (untuk pagination)
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) %s %s", personCreditFrom, where)
+	if err := r.db.QueryRowContext(ctx, countQuery, params...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count filmography: %w", err)
+	}
+
+	// Tidak ada credits: cek apakah person-nya memang ada
+	if total == 0 {
+		var exists int
+		err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM persons WHERE person_id = @p1`, personID).Scan(&exists)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to check person: %w", err)
+		}
+		if exists == 0 {
+			return nil, 0, ErrPersonNotFound
+		}
+	}
+
+	// 2. Credits untuk page ini
+	offset := (page - 1) * limit
+	query := fmt.Sprintf(`SELECT %s
+	%s
+	%s
+	ORDER BY t.startYear DESC, tp.title_id
+	OFFSET @p%d ROWS FETCH NEXT @p%d ROWS ONLY`,
+		personCreditSelect, personCreditFrom, where, len(params)+1, len(params)+2)
+	params = append(params, offset, limit)
+
+	rows, err := r.db.QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get filmography: %w", err)
+	}
+	defer rows.Close()
+
+	credits := make([]*models.PersonCredit, 0)
+	for rows.Next() {
+		credit := &models.PersonCredit{}
+		err := rows.Scan(
+			&credit.TitleID,
+			&credit.Name,
+			&credit.StartYear,
+			&credit.VoteAverage,
+			&credit.Type,
+			&credit.Category,
+			&credit.Job,
+			&credit.Characters,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan filmography credit: %w", err)
+		}
+		credits = append(credits, credit)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating filmography: %w", err)
+	}
+
+	return credits, total, nil
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"film-dashboard-api/internal/models"
+)
+
+var (
+	// ErrCommentNotFound dikembalikan kalau comment_id tidak ada, sudah dihapus, atau bukan bagian dari review
+	ErrCommentNotFound = errors.New("comment not found")
+	// ErrCommentForbidden dikembalikan kalau user mencoba mengubah comment milik user lain
+	ErrCommentForbidden = errors.New("you can only modify your own comments")
+)
+
+// reviewCommentSelect dipakai oleh semua query yang return ReviewComment
+// (urutan kolom = urutan scanReviewComment). Body comment yang sudah dihapus tidak dikirim
+const reviewCommentSelect = `
+		SELECT
+			c.comment_id,
+			c.review_id,
+			c.parent_comment_id,
+			c.user_id,
+			u.username,
+			CASE WHEN c.is_deleted = 1 THEN NULL ELSE c.body END AS body,
+			c.is_deleted,
+			CASE WHEN c.updated_at > c.created_at AND c.is_deleted = 0 THEN 1 ELSE 0 END AS is_edited,
+			(SELECT COUNT(*) FROM ReviewComments rc WHERE rc.parent_comment_id = c.comment_id AND rc.is_deleted = 0) AS reply_count,
+			c.created_at,
+			c.updated_at
+		FROM ReviewComments c
+		INNER JOIN Users u ON c.user_id = u.user_id`
+
+// scanReviewComment scan satu row dari reviewCommentSelect
+func scanReviewComment(row interface{ Scan(...interface{}) error }) (*models.ReviewComment, error) {
+	comment := &models.ReviewComment{}
+	err := row.Scan(
+		&comment.CommentID,
+		&comment.ReviewID,
+		&comment.ParentCommentID,
+		&comment.UserID,
+		&comment.Username,
+		&comment.Body,
+		&comment.IsDeleted,
+		&comment.IsEdited,
+		&comment.ReplyCount,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// getComment mengambil satu comment by ID, return ErrCommentNotFound kalau tidak ada
+func (r *ReviewRepository) getComment(commentID int) (*models.ReviewComment, error) {
+	comment, err := scanReviewComment(r.db.QueryRow(reviewCommentSelect+`
+		WHERE c.comment_id = @p1`, commentID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrCommentNotFound
+		}
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+	return comment, nil
+}
+
+// CreateComment menambahkan comment (atau reply kalau parentID tidak nil) ke sebuah review
+// Return ErrReviewNotFound kalau review tidak ada / di-hide moderator, ErrCommentNotFound kalau parent tidak ada /
+// sudah dihapus / bukan comment dari review yang sama
+func (r *ReviewRepository) CreateComment(reviewID int, userID int, parentID *int, body string) (*models.ReviewComment, error) {
+	// 1. Validate review & parent comment
+	var reviewExists, parentValid int
+	err := r.db.QueryRow(`SELECT
+		(SELECT COUNT(*) FROM Reviews WHERE review_id = @p1 AND is_hidden = 0),
+		(SELECT COUNT(*) FROM ReviewComments WHERE comment_id = @p2 AND review_id = @p1 AND is_deleted = 0)`,
+		reviewID, parentID).Scan(&reviewExists, &parentValid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate comment: %w", err)
+	}
+	if reviewExists == 0 {
+		return nil, ErrReviewNotFound
+	}
+	if parentID != nil && parentValid == 0 {
+		return nil, ErrCommentNotFound
+	}
+
+	// 2. Insert comment
+	var commentID int
+	err = r.db.QueryRow(`INSERT INTO ReviewComments (review_id, user_id, parent_comment_id, body)
+		OUTPUT INSERTED.comment_id
+		VALUES (@p1, @p2, @p3, @p4)`,
+		reviewID, userID, parentID, body).Scan(&commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	return r.getComment(commentID)
+}
+
+// verifyCommentOwner mengecek comment ada, belum dihapus, dan milik userID
+// (sama seperti DeleteReview: ambil user_id dulu, lalu bandingkan)
+// Return ErrReviewNotFound kalau review-nya di-hide moderator (konsisten dengan CreateComment)
+func (r *ReviewRepository) verifyCommentOwner(commentID int, userID int) error {
+	var ownerID int
+	var reviewHidden bool
+	query := `SELECT c.user_id, rv.is_hidden
+		FROM ReviewComments c
+		INNER JOIN Reviews rv ON rv.review_id = c.review_id
+		WHERE c.comment_id = @p1 AND c.is_deleted = 0`
+	err := r.db.QueryRow(query, commentID).Scan(&ownerID, &reviewHidden)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrCommentNotFound
+		}
+		return fmt.Errorf("failed to verify comment ownership: %w", err)
+	}
+
+	if reviewHidden {
+		return ErrReviewNotFound
+	}
+
+	if ownerID != userID {
+		return ErrCommentForbidden
+	}
+	return nil
+}
+
+// UpdateComment mengubah body comment (hanya owner)
+func (r *ReviewRepository) UpdateComment(commentID int, userID int, body string) (*models.ReviewComment, error) {
+	if err := r.verifyCommentOwner(commentID, userID); err != nil {
+		return nil, err
+	}
+
+	_, err := r.db.Exec(`UPDATE ReviewComments SET body = @p2, updated_at = GETDATE() WHERE comment_id = @p1`, commentID, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	return r.getComment(commentID)
+}
+
+// DeleteComment soft-delete comment (hanya owner). Replies tetap ada supaya thread tidak putus
+func (r *ReviewRepository) DeleteComment(commentID int, userID int) error {
+	if err := r.verifyCommentOwner(commentID, userID); err != nil {
+		return err
+	}
+
+	_, err := r.db.Exec(`UPDATE ReviewComments SET is_deleted = 1, deleted_at = GETDATE() WHERE comment_id = @p1`, commentID)
+	if err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	return nil
+}
+
+// GetComments mengambil comments sebuah review secara paged (terlama dulu, seperti thread)
+// parentID nil = top-level comments, selain itu replies dari comment tersebut
+// Comment yang sudah dihapus tetap ikut (body nil) supaya replies-nya tetap punya konteks
+// Return: comments untuk page yang diminta, total comments, dan error
+func (r *ReviewRepository) GetComments(reviewID int, parentID *int, page int, limit int) ([]*models.ReviewComment, int, error) {
+	where := `
+		WHERE c.review_id = @p1
+		  AND ((@p2 IS NULL AND c.parent_comment_id IS NULL) OR c.parent_comment_id = @p2)`
+
+	// 1. Total comments (untuk pagination)
+	var total int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM ReviewComments c`+where, reviewID, parentID).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count comments: %w", err)
+	}
+
+	// 2. Comments untuk page ini
+	query := reviewCommentSelect + where + `
+		ORDER BY c.created_at ASC, c.comment_id ASC
+		OFFSET @p3 ROWS FETCH NEXT @p4 ROWS ONLY`
+
+	rows, err := r.db.Query(query, reviewID, parentID, (page-1)*limit, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := make([]*models.ReviewComment, 0)
+	for rows.Next() {
+		comment, err := scanReviewComment(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		comments = append(comments, comment)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating comments: %w", err)
+	}
+
+	return comments, total, nil
+}
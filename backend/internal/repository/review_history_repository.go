@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"film-dashboard-api/internal/models"
+)
+
+// GetReviewHistory mengambil semua versi lama sebuah review (terbaru dulu)
+// review_text NULL (review lama tanpa teks) dikembalikan sebagai string kosong
+func (r *ReviewRepository) GetReviewHistory(reviewID int) ([]*models.ReviewRevision, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT history_id, rating, COALESCE(review_text, N''), contains_spoilers, written_at, replaced_at
+		FROM ReviewHistory
+		WHERE review_id = @p1
+		ORDER BY replaced_at DESC, history_id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review history: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := make([]*models.ReviewRevision, 0)
+	for rows.Next() {
+		var revision models.ReviewRevision
+		if err := rows.Scan(
+			&revision.HistoryID,
+			&revision.Rating,
+			&revision.ReviewText,
+			&revision.ContainsSpoilers,
+			&revision.WrittenAt,
+			&revision.ReplacedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan review revision: %w", err)
+		}
+		revisions = append(revisions, &revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating review history: %w", err)
+	}
+
+	return revisions, nil
+}
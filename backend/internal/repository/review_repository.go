@@ -3,6 +3,7 @@ package repository
 import (
 	"database/sql"
 	"fmt"
+	"math"
 
 	"film-dashboard-api/internal/models"
 )
@@ -21,11 +22,12 @@ func NewReviewRepository(db *sql.DB) *ReviewRepository {
 
 // CreateOrUpdateReview membuat atau update review (upsert pattern)
 // Jika user sudah punya review untuk title ini, update; jika belum, buat baru
-func (r *ReviewRepository) CreateOrUpdateReview(userID int, titleID string, rating int, reviewText string) (*models.Review, error) {
+func (r *ReviewRepository) CreateOrUpdateReview(userID int, titleID string, rating int, reviewText string, containsSpoilers bool) (*models.Review, error) {
 	var review models.Review
 
 	query := `
 		DECLARE @ReviewID INT;
+		DECLARE @Changed INT = 0;
 		
 		-- Check apakah user sudah punya review untuk title ini
 		SELECT @ReviewID = review_id 
@@ -34,10 +36,28 @@ func (r *ReviewRepository) CreateOrUpdateReview(userID int, titleID string, rati
 		
 		IF @ReviewID IS NOT NULL
 		BEGIN
-			-- Update review yang sudah ada
+			SET XACT_ABORT ON;
+			BEGIN TRANSACTION;
+
+			-- Simpan versi lama ke history kalau isinya berubah
+			-- (review_text dibandingkan secara binary supaya edit huruf besar/kecil juga tercatat,
+			-- NULL dianggap 0x supaya edit dari / ke teks NULL juga tercatat)
+			INSERT INTO ReviewHistory (review_id, rating, review_text, contains_spoilers, written_at)
+			SELECT review_id, rating, review_text, contains_spoilers, COALESCE(edited_at, created_at)
+			FROM Reviews
+			WHERE review_id = @ReviewID
+				AND (rating <> @p3
+					OR contains_spoilers <> @p5
+					OR ISNULL(CAST(review_text AS VARBINARY(MAX)), 0x) <> ISNULL(CAST(CAST(@p4 AS NVARCHAR(MAX)) AS VARBINARY(MAX)), 0x));
+			SET @Changed = @@ROWCOUNT;
+
+			-- Update review yang sudah ada (edited_at hanya di-set kalau ada perubahan)
 			UPDATE Reviews
-			SET rating = @p3, review_text = @p4
+			SET rating = @p3, review_text = @p4, contains_spoilers = @p5,
+				edited_at = CASE WHEN @Changed > 0 THEN GETDATE() ELSE edited_at END
 			WHERE review_id = @ReviewID;
+
+			COMMIT TRANSACTION;
 			
 			-- Return updated review
 			SELECT 
@@ -46,16 +66,18 @@ func (r *ReviewRepository) CreateOrUpdateReview(userID int, titleID string, rati
 				title_id, 
 				rating, 
 				review_text, 
+				contains_spoilers,
 				created_at, 
-				updated_at
+				updated_at,
+				edited_at
 			FROM Reviews
 			WHERE review_id = @ReviewID;
 		END
 		ELSE
 		BEGIN
 			-- Insert review baru
-			INSERT INTO Reviews (user_id, title_id, rating, review_text)
-			VALUES (@p1, @p2, @p3, @p4);
+			INSERT INTO Reviews (user_id, title_id, rating, review_text, contains_spoilers)
+			VALUES (@p1, @p2, @p3, @p4, @p5);
 			
 			-- Return newly created review
 			SELECT 
@@ -64,14 +86,16 @@ func (r *ReviewRepository) CreateOrUpdateReview(userID int, titleID string, rati
 				title_id, 
 				rating, 
 				review_text, 
+				contains_spoilers,
 				created_at, 
-				updated_at
+				updated_at,
+				edited_at
 			FROM Reviews
 			WHERE review_id = SCOPE_IDENTITY();
 		END
 	`
 
-	row := r.db.QueryRow(query, userID, titleID, rating, reviewText)
+	row := r.db.QueryRow(query, userID, titleID, rating, reviewText, containsSpoilers)
 
 	err := row.Scan(
 		&review.ReviewID,
@@ -79,8 +103,10 @@ func (r *ReviewRepository) CreateOrUpdateReview(userID int, titleID string, rati
 		&review.TitleID,
 		&review.Rating,
 		&review.ReviewText,
+		&review.ContainsSpoilers,
 		&review.CreatedAt,
 		&review.UpdatedAt,
+		&review.EditedAt,
 	)
 
 	if err != nil {
@@ -90,9 +116,27 @@ func (r *ReviewRepository) CreateOrUpdateReview(userID int, titleID string, rati
 	return &review, nil
 }
 
-// GetReviewsByTitle mengambil semua review untuk sebuah title
-func (r *ReviewRepository) GetReviewsByTitle(titleID string) ([]models.ReviewResponse, error) {
-	query := `
+// reviewSorts mapping sort option ke ORDER BY clause untuk listing review
+// review_id selalu jadi tie-breaker supaya urutan stabil antar page
+var reviewSorts = map[string]string{
+	"newest":  "ORDER BY r.created_at DESC, r.review_id DESC",
+	"oldest":  "ORDER BY r.created_at ASC, r.review_id ASC",
+	"highest": "ORDER BY r.rating DESC, r.created_at DESC, r.review_id DESC",
+	"lowest":  "ORDER BY r.rating ASC, r.created_at DESC, r.review_id DESC",
+	"helpful": "ORDER BY COALESCE(vc.helpful_count, 0) DESC, COALESCE(vc.not_helpful_count, 0) ASC, r.created_at DESC, r.review_id DESC",
+}
+
+// reviewOrderBy return ORDER BY clause untuk sort tertentu (fallback ke "newest")
+func reviewOrderBy(sort string) string {
+	if orderBy, ok := reviewSorts[sort]; ok {
+		return orderBy
+	}
+	return reviewSorts["newest"]
+}
+
+// reviewListSelect dipakai oleh listing review (urutan kolom = urutan scanReview)
+// @p1 selalu viewer user_id untuk kolom my_vote (0 = tanpa login, my_vote selalu NULL)
+const reviewListSelect = `
 		SELECT 
 			r.review_id,
 			r.user_id,
@@ -101,134 +145,205 @@ func (r *ReviewRepository) GetReviewsByTitle(titleID string) ([]models.ReviewRes
 			r.rating,
 			r.review_text,
 			r.created_at,
-			r.updated_at
+			r.updated_at,
+			COALESCE(vc.helpful_count, 0) AS helpful_count,
+			COALESCE(vc.not_helpful_count, 0) AS not_helpful_count,
+			CASE mv.is_helpful WHEN 1 THEN 'helpful' WHEN 0 THEN 'not_helpful' END AS my_vote,
+			(SELECT COUNT(*) FROM ReviewComments c WHERE c.review_id = r.review_id AND c.is_deleted = 0) AS comment_count,
+			r.is_hidden,
+			r.contains_spoilers,
+			r.edited_at
 		FROM Reviews r
 		INNER JOIN Users u ON r.user_id = u.user_id
-		WHERE r.title_id = @p1
-		ORDER BY r.created_at DESC
-	`
-
-	rows, err := r.db.Query(query, titleID)
+		OUTER APPLY (
+			SELECT
+				SUM(CASE WHEN v.is_helpful = 1 THEN 1 ELSE 0 END) AS helpful_count,
+				SUM(CASE WHEN v.is_helpful = 0 THEN 1 ELSE 0 END) AS not_helpful_count
+			FROM ReviewVotes v
+			WHERE v.review_id = r.review_id
+		) vc
+		LEFT JOIN ReviewVotes mv ON mv.review_id = r.review_id AND mv.user_id = @p1`
+
+// scanReview scan satu row dari reviewListSelect
+func scanReview(row interface{ Scan(...interface{}) error }) (*models.ReviewResponse, error) {
+	var review models.ReviewResponse
+	err := row.Scan(
+		&review.ReviewID,
+		&review.UserID,
+		&review.Username,
+		&review.TitleID,
+		&review.Rating,
+		&review.ReviewText,
+		&review.CreatedAt,
+		&review.UpdatedAt,
+		&review.HelpfulCount,
+		&review.NotHelpfulCount,
+		&review.MyVote,
+		&review.CommentCount,
+		&review.IsHidden,
+		&review.ContainsSpoilers,
+		&review.EditedAt,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get reviews by title: %w", err)
+		return nil, err
 	}
-	defer rows.Close()
+	review.IsEdited = review.EditedAt != nil
+	return &review, nil
+}
 
-	var reviews []models.ReviewResponse
+// scanReviewRows scan semua row dari reviewListSelect
+func scanReviewRows(rows *sql.Rows) ([]models.ReviewResponse, error) {
+	reviews := make([]models.ReviewResponse, 0)
 	for rows.Next() {
-		var review models.ReviewResponse
-		err := rows.Scan(
-			&review.ReviewID,
-			&review.UserID,
-			&review.Username,
-			&review.TitleID,
-			&review.Rating,
-			&review.ReviewText,
-			&review.CreatedAt,
-			&review.UpdatedAt,
-		)
+		review, err := scanReview(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan review: %w", err)
 		}
-		reviews = append(reviews, review)
+		reviews = append(reviews, *review)
 	}
 
-	if err = rows.Err(); err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating reviews: %w", err)
 	}
 
 	return reviews, nil
 }
 
-// GetReviewsByUser mengambil semua review yang ditulis oleh user tertentu
-func (r *ReviewRepository) GetReviewsByUser(userID int) ([]models.ReviewResponse, error) {
+// GetReviewsByTitle mengambil review untuk sebuah title secara paged
+// sort: newest (default), oldest, highest, lowest, helpful
+// viewerID = user yang sedang login untuk MyVote (0 = tanpa login)
+// Review yang di-hide moderator tidak ikut, kecuali milik viewer sendiri (author tetap bisa melihatnya)
+// Return: reviews untuk page yang diminta, total review yang terlihat oleh viewer, dan error
+func (r *ReviewRepository) GetReviewsByTitle(viewerID int, titleID string, sort string, page int, limit int) ([]models.ReviewResponse, int, error) {
+	where := `
+		WHERE r.title_id = @p2 AND (r.is_hidden = 0 OR r.user_id = @p1)`
+
+	// 1. Total review (untuk pagination), filter sama dengan query page
+	var total int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM Reviews r`+where, viewerID, titleID).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count reviews by title: %w", err)
+	}
+	if total == 0 {
+		return []models.ReviewResponse{}, 0, nil
+	}
+
+	// 2. Reviews untuk page ini
+	query := reviewListSelect + where + `
+		` + reviewOrderBy(sort) + `
+		OFFSET @p3 ROWS FETCH NEXT @p4 ROWS ONLY`
+
+	rows, err := r.db.Query(query, viewerID, titleID, (page-1)*limit, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get reviews by title: %w", err)
+	}
+	defer rows.Close()
+
+	reviews, err := scanReviewRows(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return reviews, total, nil
+}
+
+// GetRatingSummary menghitung community average, jumlah review, dan histogram rating 1-10 untuk sebuah title
+// Review yang di-hide moderator tidak dihitung
+func (r *ReviewRepository) GetRatingSummary(titleID string) (*models.RatingSummary, error) {
 	query := `
-		SELECT 
-			r.review_id,
-			r.user_id,
-			u.username,
-			r.title_id,
-			r.rating,
-			r.review_text,
-			r.created_at,
-			r.updated_at
-		FROM Reviews r
-		INNER JOIN Users u ON r.user_id = u.user_id
-		WHERE r.user_id = @p1
-		ORDER BY r.created_at DESC
+		SELECT rating, COUNT(*)
+		FROM Reviews
+		WHERE title_id = @p1 AND is_hidden = 0
+		GROUP BY rating
 	`
 
-	rows, err := r.db.Query(query, userID)
+	rows, err := r.db.Query(query, titleID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get reviews by user: %w", err)
+		return nil, fmt.Errorf("failed to get rating summary: %w", err)
 	}
 	defer rows.Close()
 
-	var reviews []models.ReviewResponse
+	// Histogram selalu 10 bucket supaya frontend tidak perlu isi rating yang kosong
+	summary := &models.RatingSummary{
+		Histogram: make([]*models.RatingBucket, 10),
+	}
+	for i := range summary.Histogram {
+		summary.Histogram[i] = &models.RatingBucket{Rating: i + 1}
+	}
+
+	total := 0
 	for rows.Next() {
-		var review models.ReviewResponse
-		err := rows.Scan(
-			&review.ReviewID,
-			&review.UserID,
-			&review.Username,
-			&review.TitleID,
-			&review.Rating,
-			&review.ReviewText,
-			&review.CreatedAt,
-			&review.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan review: %w", err)
+		var rating, count int
+		if err := rows.Scan(&rating, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan rating summary: %w", err)
+		}
+		if rating < 1 || rating > 10 {
+			continue
 		}
-		reviews = append(reviews, review)
+		summary.Histogram[rating-1].Count = count
+		summary.Count += count
+		total += rating * count
 	}
-
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating reviews: %w", err)
+		return nil, fmt.Errorf("error iterating rating summary: %w", err)
 	}
 
-	return reviews, nil
+	if summary.Count > 0 {
+		average := math.Round(float64(total)/float64(summary.Count)*100) / 100
+		summary.Average = &average
+	}
+
+	return summary, nil
 }
 
-// GetReviewByID mengambil single review by ID
-func (r *ReviewRepository) GetReviewByID(reviewID int) (*models.ReviewResponse, error) {
-	var review models.ReviewResponse
+// GetReviewsByUser mengambil review yang ditulis oleh user tertentu secara paged
+// Termasuk review yang di-hide moderator (author tetap bisa melihat review-nya sendiri)
+// Return: reviews untuk page yang diminta, total review user, dan error
+func (r *ReviewRepository) GetReviewsByUser(userID int, sort string, page int, limit int) ([]models.ReviewResponse, int, error) {
+	// 1. Total review (untuk pagination)
+	var total int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM Reviews WHERE user_id = @p1`, userID).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count reviews by user: %w", err)
+	}
 
-	query := `
-		SELECT 
-			r.review_id,
-			r.user_id,
-			u.username,
-			r.title_id,
-			r.rating,
-			r.review_text,
-			r.created_at,
-			r.updated_at
-		FROM Reviews r
-		INNER JOIN Users u ON r.user_id = u.user_id
-		WHERE r.review_id = @p1
-	`
+	// 2. Reviews untuk page ini
+	// Viewer = user itu sendiri (@p1 dipakai untuk my_vote sekaligus filter)
+	query := reviewListSelect + `
+		WHERE r.user_id = @p1
+		` + reviewOrderBy(sort) + `
+		OFFSET @p2 ROWS FETCH NEXT @p3 ROWS ONLY`
 
-	row := r.db.QueryRow(query, reviewID)
-	err := row.Scan(
-		&review.ReviewID,
-		&review.UserID,
-		&review.Username,
-		&review.TitleID,
-		&review.Rating,
-		&review.ReviewText,
-		&review.CreatedAt,
-		&review.UpdatedAt,
-	)
+	rows, err := r.db.Query(query, userID, (page-1)*limit, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get reviews by user: %w", err)
+	}
+	defer rows.Close()
 
+	reviews, err := scanReviewRows(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return reviews, total, nil
+}
+
+// GetReviewByID mengambil single review by ID (termasuk yang di-hide)
+// viewerID dipakai untuk kolom my_vote (0 = tanpa login)
+// Return ErrReviewNotFound kalau review tidak ada
+func (r *ReviewRepository) GetReviewByID(viewerID int, reviewID int) (*models.ReviewResponse, error) {
+	query := reviewListSelect + `
+		WHERE r.review_id = @p2
+	`
+
+	review, err := scanReview(r.db.QueryRow(query, viewerID, reviewID))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("review not found")
+			return nil, ErrReviewNotFound
 		}
 		return nil, fmt.Errorf("failed to get review: %w", err)
 	}
 
-	return &review, nil
+	return review, nil
 }
 
 // DeleteReview menghapus review by ID
@@ -265,35 +380,11 @@ func (r *ReviewRepository) DeleteReview(reviewID int, userID int) error {
 // GetUserReviewForTitle mengambil review yang ditulis user untuk title tertentu
 // Digunakan untuk check apakah user sudah review title ini
 func (r *ReviewRepository) GetUserReviewForTitle(userID int, titleID string) (*models.ReviewResponse, error) {
-	var review models.ReviewResponse
-
-	query := `
-		SELECT 
-			r.review_id,
-			r.user_id,
-			u.username,
-			r.title_id,
-			r.rating,
-			r.review_text,
-			r.created_at,
-			r.updated_at
-		FROM Reviews r
-		INNER JOIN Users u ON r.user_id = u.user_id
+	query := reviewListSelect + `
 		WHERE r.user_id = @p1 AND r.title_id = @p2
 	`
 
-	row := r.db.QueryRow(query, userID, titleID)
-	err := row.Scan(
-		&review.ReviewID,
-		&review.UserID,
-		&review.Username,
-		&review.TitleID,
-		&review.Rating,
-		&review.ReviewText,
-		&review.CreatedAt,
-		&review.UpdatedAt,
-	)
-
+	review, err := scanReview(r.db.QueryRow(query, userID, titleID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil // No review yet, not an error
@@ -301,5 +392,5 @@ func (r *ReviewRepository) GetUserReviewForTitle(userID int, titleID string) (*m
 		return nil, fmt.Errorf("failed to get user review: %w", err)
 	}
 
-	return &review, nil
+	return review, nil
 }
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"film-dashboard-api/internal/models"
+)
+
+// GetReviewTarget mengecek title_id yang akan di-review ada di table titles
+// Return: isEpisode (title_id ada di table episodes), ErrTitleNotFound kalau title tidak ada
+func (r *ReviewRepository) GetReviewTarget(titleID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var titleCount, episodeCount int
+	err := r.db.QueryRowContext(ctx, `SELECT
+		(SELECT COUNT(*) FROM titles WHERE title_id = @p1),
+		(SELECT COUNT(*) FROM episodes WHERE title_id = @p1)`,
+		titleID).Scan(&titleCount, &episodeCount)
+	if err != nil {
+		return false, fmt.Errorf("failed to check review target: %w", err)
+	}
+	if titleCount == 0 {
+		return false, ErrTitleNotFound
+	}
+	return episodeCount > 0, nil
+}
+
+// FindOrphanReviews mengambil review yang title_id-nya tidak ada di table titles
+// (models.OrphanReasonMissingTitle) dan, kalau includeEpisodes true, review untuk episode
+// (models.OrphanReasonEpisode). Dipakai oleh maintenance command cmd/review-orphans
+func (r *ReviewRepository) FindOrphanReviews(includeEpisodes bool) ([]*models.OrphanReview, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	query := `
+		SELECT
+			r.review_id,
+			r.user_id,
+			u.username,
+			r.title_id,
+			r.rating,
+			r.created_at,
+			CASE WHEN t.title_id IS NULL THEN 'missing_title' ELSE 'episode' END AS reason
+		FROM Reviews r
+		INNER JOIN Users u ON r.user_id = u.user_id
+		LEFT JOIN titles t ON t.title_id = r.title_id
+		WHERE t.title_id IS NULL
+			OR (@p1 = 1 AND EXISTS (SELECT 1 FROM episodes e WHERE e.title_id = r.title_id))
+		ORDER BY reason, r.title_id, r.review_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, includeEpisodes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find orphan reviews: %w", err)
+	}
+	defer rows.Close()
+
+	orphans := make([]*models.OrphanReview, 0)
+	for rows.Next() {
+		var orphan models.OrphanReview
+		if err := rows.Scan(
+			&orphan.ReviewID,
+			&orphan.UserID,
+			&orphan.Username,
+			&orphan.TitleID,
+			&orphan.Rating,
+			&orphan.CreatedAt,
+			&orphan.Reason,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan orphan review: %w", err)
+		}
+		orphans = append(orphans, &orphan)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating orphan reviews: %w", err)
+	}
+
+	return orphans, nil
+}
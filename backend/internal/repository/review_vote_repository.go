@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"film-dashboard-api/internal/models"
+)
+
+// ErrReviewNotFound dikembalikan kalau review_id tidak ada di table Reviews
+var ErrReviewNotFound = errors.New("review not found")
+
+// GetReviewOwner mengambil user_id pemilik review, return ErrReviewNotFound kalau review tidak ada
+func (r *ReviewRepository) GetReviewOwner(reviewID int) (int, error) {
+	var ownerID int
+	err := r.db.QueryRow(`SELECT user_id FROM Reviews WHERE review_id = @p1`, reviewID).Scan(&ownerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, ErrReviewNotFound
+		}
+		return 0, fmt.Errorf("failed to get review owner: %w", err)
+	}
+	return ownerID, nil
+}
+
+// GetReviewVisibility mengambil user_id pemilik review dan status is_hidden (moderation)
+// Return ErrReviewNotFound kalau review tidak ada
+func (r *ReviewRepository) GetReviewVisibility(reviewID int) (int, bool, error) {
+	var ownerID int
+	var isHidden bool
+	err := r.db.QueryRow(`SELECT user_id, is_hidden FROM Reviews WHERE review_id = @p1`, reviewID).Scan(&ownerID, &isHidden)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, ErrReviewNotFound
+		}
+		return 0, false, fmt.Errorf("failed to get review visibility: %w", err)
+	}
+	return ownerID, isHidden, nil
+}
+
+// SetReviewVote menyimpan vote user untuk sebuah review (upsert - vote lama diganti)
+// Return ErrReviewNotFound kalau review tidak ada atau sedang di-hide moderator
+func (r *ReviewRepository) SetReviewVote(reviewID int, userID int, isHelpful bool) error {
+	query := `
+		MERGE ReviewVotes WITH (HOLDLOCK) AS target
+		USING (
+			SELECT @p1 AS review_id, @p2 AS user_id
+			WHERE EXISTS (SELECT 1 FROM Reviews WHERE review_id = @p1 AND is_hidden = 0)
+		) AS source
+			ON target.review_id = source.review_id AND target.user_id = source.user_id
+		WHEN MATCHED THEN
+			UPDATE SET is_helpful = @p3, updated_at = GETDATE()
+		WHEN NOT MATCHED THEN
+			INSERT (review_id, user_id, is_helpful) VALUES (@p1, @p2, @p3);
+	`
+
+	result, err := r.db.Exec(query, reviewID, userID, isHelpful)
+	if err != nil {
+		return fmt.Errorf("failed to save review vote: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrReviewNotFound
+	}
+	return nil
+}
+
+// DeleteReviewVote menghapus vote user untuk sebuah review
+// Idempotent: review yang memang belum di-vote tidak dianggap error
+func (r *ReviewRepository) DeleteReviewVote(reviewID int, userID int) error {
+	_, err := r.db.Exec(`DELETE FROM ReviewVotes WHERE review_id = @p1 AND user_id = @p2`, reviewID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete review vote: %w", err)
+	}
+	return nil
+}
+
+// GetReviewVoteSummary menghitung total vote sebuah review + vote milik viewer
+func (r *ReviewRepository) GetReviewVoteSummary(reviewID int, viewerID int) (*models.ReviewVoteSummary, error) {
+	summary := &models.ReviewVoteSummary{ReviewID: reviewID}
+
+	query := `
+		SELECT
+			COALESCE(SUM(CASE WHEN is_helpful = 1 THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN is_helpful = 0 THEN 1 ELSE 0 END), 0),
+			MAX(CASE WHEN user_id = @p2 THEN CASE is_helpful WHEN 1 THEN 'helpful' ELSE 'not_helpful' END END)
+		FROM ReviewVotes
+		WHERE review_id = @p1
+	`
+
+	err := r.db.QueryRow(query, reviewID, viewerID).Scan(
+		&summary.HelpfulCount,
+		&summary.NotHelpfulCount,
+		&summary.MyVote,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review vote summary: %w", err)
+	}
+	return summary, nil
+}
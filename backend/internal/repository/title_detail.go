@@ -0,0 +1,317 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"film-dashboard-api/internal/models"
+)
+
+// titleDetailSection adalah satu child query dari title detail (genres, languages, dst)
+// load mengisi field miliknya sendiri di TitleDetailResponse, jadi aman dijalankan paralel
+// Rows di-scan ke slice lokal dan baru di-assign kalau query sukses, supaya section yang gagal
+// di tengah jalan tidak meninggalkan data setengah jadi (tetap kosong + tercatat di FailedSections)
+type titleDetailSection struct {
+	name string
+	load func(ctx context.Context) error
+}
+
+// GetTitleDetail mengambil detail lengkap title berdasarkan title_id
+// 1. Query detail utama dulu (kalau tidak ada, return response dengan Detail nil)
+// 2. Semua child query (genres, languages, countries, ...) dijalankan paralel di bawah ctx
+// Child query yang gagal tidak menggagalkan seluruh response, tapi namanya dicatat
+// di FailedSections supaya client tahu data mana yang tidak lengkap
+func (r *TitleRepository) GetTitleDetail(ctx context.Context, titleID string) (*models.TitleDetailResponse, error) {
+	response := &models.TitleDetailResponse{
+		Genres:          make([]*models.Genre, 0),
+		Languages:       make([]*models.Language, 0),
+		Countries:       make([]*models.ProductionCountry, 0),
+		Companies:       make([]*models.ProductionCompany, 0),
+		Networks:        make([]*models.Network, 0),
+		AirDates:        make([]*models.AirDate, 0),
+		CastAndCrew:     make([]*models.CastCrew, 0),
+		AlternateTitles: make([]*models.AlternateTitle, 0),
+		Links:           make([]*models.ExternalLink, 0),
+		FailedSections:  make([]string, 0),
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// 1. Get title detail
+	query := `SELECT
+        t.title_id,
+        t.name,
+        t.original_name,
+        t.overview,
+        t.popularity,
+        t.vote_average,
+        t.vote_count,
+        t.runtimeMinutes,
+        t.startYear,
+        t.endYear,
+        t.number_of_seasons,
+        t.number_of_episodes,
+        ty.type_name,
+        s.status_name,
+        t.tagline,
+        cs.community_score,
+        cs.review_count
+    FROM titles t
+    LEFT JOIN types ty ON t.type_id = ty.type_id
+    LEFT JOIN status s ON t.status_id = s.status_id
+    LEFT JOIN TitleCommunityScores cs ON cs.title_id = t.title_id
+    WHERE t.title_id = @p1`
+
+	detail := &models.TitleDetail{}
+	err := r.db.QueryRowContext(ctx, query, titleID).Scan(
+		&detail.TitleID,
+		&detail.Name,
+		&detail.OriginalName,
+		&detail.Overview,
+		&detail.Popularity,
+		&detail.VoteAverage,
+		&detail.VoteCount,
+		&detail.RuntimeMinutes,
+		&detail.StartYear,
+		&detail.EndYear,
+		&detail.NumberSeasons,
+		&detail.NumberEpisodes,
+		&detail.Type,
+		&detail.Status,
+		&detail.Tagline,
+		&detail.CommunityScore,
+		&detail.CommunityReviewCount,
+	)
+	if err == sql.ErrNoRows {
+		return response, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get title detail: %w", err)
+	}
+	response.Detail = detail
+
+	// 2. Child queries (paralel)
+	sections := []titleDetailSection{
+		{"genres", func(ctx context.Context) error {
+			genres := make([]*models.Genre, 0)
+			err := r.queryDetailRows(ctx, `SELECT gt.genre_name
+			FROM genres g
+			JOIN genre_types gt ON g.genre_type_id = gt.genre_type_id
+			WHERE g.title_id = @p1`, titleID, func(rows *sql.Rows) error {
+				genre := &models.Genre{}
+				if err := rows.Scan(&genre.GenreName); err != nil {
+					return err
+				}
+				genres = append(genres, genre)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			response.Genres = genres
+			return nil
+		}},
+		{"languages", func(ctx context.Context) error {
+			languages := make([]*models.Language, 0)
+			err := r.queryDetailRows(ctx, `SELECT lt.language_name
+			FROM languages l
+			JOIN language_types lt ON l.language_type_id = lt.language_type_id
+			WHERE l.title_id = @p1`, titleID, func(rows *sql.Rows) error {
+				lang := &models.Language{}
+				if err := rows.Scan(&lang.LanguageName); err != nil {
+					return err
+				}
+				languages = append(languages, lang)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			response.Languages = languages
+			return nil
+		}},
+		{"countries", func(ctx context.Context) error {
+			countries := make([]*models.ProductionCountry, 0)
+			err := r.queryDetailRows(ctx, `SELECT pct.production_country_name
+			FROM production_countries pc
+			JOIN production_country_types pct ON pc.production_country_type_id = pct.production_country_type_id
+			WHERE pc.title_id = @p1`, titleID, func(rows *sql.Rows) error {
+				country := &models.ProductionCountry{}
+				if err := rows.Scan(&country.CountryName); err != nil {
+					return err
+				}
+				countries = append(countries, country)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			response.Countries = countries
+			return nil
+		}},
+		{"companies", func(ctx context.Context) error {
+			companies := make([]*models.ProductionCompany, 0)
+			err := r.queryDetailRows(ctx, `SELECT pct.production_company_name
+			FROM production_companies pc
+			JOIN production_company_types pct ON pc.production_company_type_id = pct.production_company_type_id
+			WHERE pc.title_id = @p1`, titleID, func(rows *sql.Rows) error {
+				company := &models.ProductionCompany{}
+				if err := rows.Scan(&company.CompanyName); err != nil {
+					return err
+				}
+				companies = append(companies, company)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			response.Companies = companies
+			return nil
+		}},
+		{"networks", func(ctx context.Context) error {
+			networks := make([]*models.Network, 0)
+			err := r.queryDetailRows(ctx, `SELECT nt.network_name
+			FROM networks n
+			JOIN network_types nt ON n.network_type_id = nt.network_type_id
+			WHERE n.title_id = @p1`, titleID, func(rows *sql.Rows) error {
+				network := &models.Network{}
+				if err := rows.Scan(&network.NetworkName); err != nil {
+					return err
+				}
+				networks = append(networks, network)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			response.Networks = networks
+			return nil
+		}},
+		{"air_dates", func(ctx context.Context) error {
+			airDates := make([]*models.AirDate, 0)
+			err := r.queryDetailRows(ctx, `SELECT a.date, a.is_first, e.seasonNumber, e.episodeNumber
+			FROM air_dates a
+			LEFT JOIN episodes e ON a.title_id = e.title_id
+			WHERE a.title_id = @p1`, titleID, func(rows *sql.Rows) error {
+				airDate := &models.AirDate{}
+				if err := rows.Scan(&airDate.Date, &airDate.IsFirst, &airDate.SeasonNumber, &airDate.EpisodeNumber); err != nil {
+					return err
+				}
+				airDates = append(airDates, airDate)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			response.AirDates = airDates
+			return nil
+		}},
+		{"cast_and_crew", func(ctx context.Context) error {
+			castAndCrew := make([]*models.CastCrew, 0)
+			err := r.queryDetailRows(ctx, `SELECT tp.person_id, tp.ordering, p.primaryName AS person_name, tp.category AS job_category, tp.characters
+			FROM title_principals tp
+			JOIN persons p ON tp.person_id = p.person_id
+			WHERE tp.title_id = @p1
+			ORDER BY tp.ordering`, titleID, func(rows *sql.Rows) error {
+				castCrew := &models.CastCrew{}
+				if err := rows.Scan(&castCrew.PersonID, &castCrew.Ordering, &castCrew.PersonName, &castCrew.JobCategory, &castCrew.Characters); err != nil {
+					return err
+				}
+				castAndCrew = append(castAndCrew, castCrew)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			response.CastAndCrew = castAndCrew
+			return nil
+		}},
+		{"parent", func(ctx context.Context) error {
+			parent := &models.EpisodeParent{}
+			err := r.db.QueryRowContext(ctx, `SELECT e.parent_title_id, p.name, e.seasonNumber, e.episodeNumber
+			FROM episodes e
+			LEFT JOIN titles p ON p.title_id = e.parent_title_id
+			WHERE e.title_id = @p1`, titleID).Scan(&parent.ParentTitleID, &parent.ParentName, &parent.SeasonNumber, &parent.EpisodeNumber)
+			if err == sql.ErrNoRows {
+				return nil // bukan episode
+			}
+			if err != nil {
+				return err
+			}
+			response.Parent = parent
+			return nil
+		}},
+		{"alternate_titles", func(ctx context.Context) error {
+			titles, err := r.queryAlternateTitles(ctx, titleID, "", "")
+			if err != nil {
+				return err
+			}
+			response.AlternateTitles = titles
+			return nil
+		}},
+		{"links", func(ctx context.Context) error {
+			links := make([]*models.ExternalLink, 0)
+			err := r.queryDetailRows(ctx, `SELECT l.link_type_id, lt.link_type, l.Link
+			FROM links l
+			LEFT JOIN link_types lt ON l.link_type_id = lt.link_type_id
+			WHERE l.title_id = @p1 AND l.Link IS NOT NULL`, titleID, func(rows *sql.Rows) error {
+				link := &models.ExternalLink{}
+				if err := rows.Scan(&link.LinkTypeID, &link.LinkType, &link.URL); err != nil {
+					return err
+				}
+				links = append(links, link)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			response.Links = links
+			return nil
+		}},
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for _, section := range sections {
+		wg.Add(1)
+		go func(section titleDetailSection) {
+			defer wg.Done()
+			if err := section.load(ctx); err != nil {
+				fmt.Printf("⚠️  Title detail %s query error (%s): %v\n", section.name, titleID, err)
+				mu.Lock()
+				response.FailedSections = append(response.FailedSections, section.name)
+				mu.Unlock()
+			}
+		}(section)
+	}
+	wg.Wait()
+
+	// Urutan goroutine selesai tidak tentu, sort supaya response deterministik
+	sort.Strings(response.FailedSections)
+	response.Partial = len(response.FailedSections) > 0
+
+	return response, nil
+}
+
+// queryDetailRows menjalankan query dengan satu parameter (title_id) dan memanggil scan untuk setiap row
+// Error dari query, scan, maupun iterasi dikembalikan (tidak di-swallow)
+func (r *TitleRepository) queryDetailRows(ctx context.Context, query string, titleID string, scan func(rows *sql.Rows) error) error {
+	rows, err := r.db.QueryContext(ctx, query, titleID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,299 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"film-dashboard-api/internal/models"
+)
+
+// titleFilterQuery menyimpan kondisi WHERE dan parameter untuk filter titles
+// Dipakai bersama oleh FilterTitles dan GetFilterTitlesCount supaya logic filter
+// selalu sama antara page query dan count query
+//
+// Semantics: OR di dalam satu facet (Drama OR Comedy), AND antar facet (genre AND type)
+type titleFilterQuery struct {
+	joins      string
+	conditions []string
+	params     []interface{}
+}
+
+// newTitleFilterQuery membangun kondisi filter dari FilterRequest
+// Semua value di-bind sebagai parameter (@p1, @p2, ...) - tidak ada string concat dari input user
+func newTitleFilterQuery(filter *models.FilterRequest) *titleFilterQuery {
+	q := &titleFilterQuery{}
+
+	if search := searchTerm(filter.Q); search != "" {
+		q.addSearch(search)
+	}
+	if ids := cleanIDs(filter.GenreIDs); len(ids) > 0 {
+		q.add(fmt.Sprintf(`EXISTS (SELECT 1 FROM genres g
+			WHERE g.title_id = t.title_id AND g.genre_type_id IN (%s))`, q.bindList(ids)))
+	}
+	if ids := cleanIDs(filter.TypeIDs); len(ids) > 0 {
+		q.add(fmt.Sprintf("t.type_id IN (%s)", q.bindList(ids)))
+	}
+	if ids := cleanIDs(filter.StatusIDs); len(ids) > 0 {
+		q.add(fmt.Sprintf("t.status_id IN (%s)", q.bindList(ids)))
+	}
+	if ids := cleanIDs(filter.OriginCountryIDs); len(ids) > 0 {
+		q.add(fmt.Sprintf(`EXISTS (SELECT 1 FROM production_countries oc
+			WHERE oc.title_id = t.title_id AND oc.origin_country_type_id IN (%s))`, q.bindList(ids)))
+	}
+	if ids := cleanIDs(filter.ProductionCountryIDs); len(ids) > 0 {
+		q.add(fmt.Sprintf(`EXISTS (SELECT 1 FROM production_countries pc
+			WHERE pc.title_id = t.title_id AND pc.production_country_type_id IN (%s))`, q.bindList(ids)))
+	}
+	if filter.Year != nil {
+		q.add("t.startYear = " + q.bind(*filter.Year))
+	}
+
+	// Range filters (inclusive)
+	if filter.YearFrom != nil {
+		q.add("t.startYear >= " + q.bind(*filter.YearFrom))
+	}
+	if filter.YearTo != nil {
+		q.add("t.startYear <= " + q.bind(*filter.YearTo))
+	}
+	if filter.RatingMin != nil {
+		q.add("t.vote_average >= " + q.bind(*filter.RatingMin))
+	}
+	if filter.RatingMax != nil {
+		q.add("t.vote_average <= " + q.bind(*filter.RatingMax))
+	}
+	if filter.RuntimeMin != nil {
+		q.add("t.runtimeMinutes >= " + q.bind(*filter.RuntimeMin))
+	}
+	if filter.RuntimeMax != nil {
+		q.add("t.runtimeMinutes <= " + q.bind(*filter.RuntimeMax))
+	}
+	if filter.MinVotes != nil {
+		q.add("t.vote_count >= " + q.bind(*filter.MinVotes))
+	}
+
+	// Toggles (BIT columns)
+	if filter.Adult != nil {
+		q.add("t.adult = " + q.bind(*filter.Adult))
+	}
+	if filter.InProduction != nil {
+		q.add("t.in_production = " + q.bind(*filter.InProduction))
+	}
+
+	return q
+}
+
+// add menambahkan satu kondisi ke WHERE clause
+func (q *titleFilterQuery) add(condition string) {
+	q.conditions = append(q.conditions, condition)
+}
+
+// bind menambahkan parameter dan return placeholder-nya (@pN)
+func (q *titleFilterQuery) bind(value interface{}) string {
+	q.params = append(q.params, value)
+	return fmt.Sprintf("@p%d", len(q.params))
+}
+
+// bindList bind setiap value dan return placeholder yang dipisah koma (untuk IN (...))
+func (q *titleFilterQuery) bindList(values []string) string {
+	placeholders := make([]string, len(values))
+	for i, v := range values {
+		placeholders[i] = q.bind(v)
+	}
+	return strings.Join(placeholders, ", ")
+}
+
+// searchTerm mengubah keyword user jadi phrase search untuk CONTAINS/CONTAINSTABLE
+// (sama dengan sp_SearchTitles: tanda kutip dibuang supaya tidak merusak syntax full-text)
+// Return string kosong kalau keyword kosong
+func searchTerm(keyword string) string {
+	keyword = strings.TrimSpace(strings.ReplaceAll(keyword, `"`, ""))
+	if keyword == "" {
+		return ""
+	}
+	return `"` + keyword + `"`
+}
+
+// addSearch membatasi hasil ke titles yang match full-text search di name/original_name
+// atau di alternate_titles. Rank tertinggi dari kedua index tersedia sebagai sr.relevance
+// (dipakai oleh sort "relevance")
+func (q *titleFilterQuery) addSearch(search string) {
+	term := q.bind(search)
+	q.joins += fmt.Sprintf(`
+	JOIN (
+		SELECT m.title_id, MAX(m.rank) AS relevance
+		FROM (
+			SELECT ct.[KEY] AS title_id, ct.RANK AS rank
+			FROM CONTAINSTABLE(titles, (name, original_name), %[1]s) ct
+			UNION ALL
+			SELECT ct.[KEY], ct.RANK
+			FROM CONTAINSTABLE(alternate_titles, title, %[1]s) ct
+		) m
+		GROUP BY m.title_id
+	) sr ON sr.title_id = t.title_id`, term)
+}
+
+// fromSQL return FROM clause lengkap (titleFilterFrom + join tambahan, contoh: search)
+func (q *titleFilterQuery) fromSQL() string {
+	return titleFilterFrom + q.joins
+}
+
+// whereSQL return WHERE clause lengkap (atau string kosong kalau tidak ada filter)
+func (q *titleFilterQuery) whereSQL() string {
+	if len(q.conditions) == 0 {
+		return ""
+	}
+	return "\n\tWHERE " + strings.Join(q.conditions, "\n\t  AND ")
+}
+
+// titleFilterFrom adalah base FROM clause untuk semua filter query
+// dbo.FilterTitles() sama dengan yang dipakai sp_filter_titles_filmcard & sp_SearchTitles
+// TitleCommunityScores di-LEFT JOIN untuk kolom community_score dan sort "community"
+const titleFilterFrom = `FROM titles t
+	JOIN dbo.FilterTitles() ft ON ft.title_id = t.title_id
+	LEFT JOIN TitleCommunityScores cs ON cs.title_id = t.title_id`
+
+// titleSortSpec mendefinisikan satu opsi SortBy
+// expr adalah kolom mentah (tanpa COALESCE) supaya keyset condition bisa seek di index (expr, title_id)
+// nullable = kolom bisa NULL; urutan NULL mengikuti default SQL Server
+// (ASC: NULL di awal, DESC: NULL di akhir) dan ditangani eksplisit di addKeyset
+type titleSortSpec struct {
+	expr     string
+	desc     bool
+	nullable bool
+}
+
+// titleSorts mapping SortBy ke sort expression (sama dengan sp_filter_titles)
+// "community" = community score (Bayesian average imported votes + review lokal)
+// Default: vote_count DESC. title_id selalu jadi tie-breaker supaya urutan stabil antar page
+// "relevance" hanya valid kalau ada search keyword (sr berasal dari addSearch)
+// Index pendukung: migration 0012_add_title_sort_indexes.sql
+var titleSorts = map[string]titleSortSpec{
+	"community":  {expr: "cs.community_score", desc: true, nullable: true},
+	"name":       {expr: "t.name", desc: false, nullable: true},
+	"popularity": {expr: "t.popularity", desc: true, nullable: true},
+	"rating":     {expr: "t.vote_average", desc: true, nullable: true},
+	"released":   {expr: "t.startYear", desc: true, nullable: true},
+	"relevance":  {expr: "sr.relevance", desc: true},
+	"votes":      {expr: "t.vote_count", desc: true, nullable: true},
+}
+
+// titleSort return titleSortSpec untuk SortBy tertentu (fallback ke "votes")
+func titleSort(sortBy string) titleSortSpec {
+	if spec, ok := titleSorts[sortBy]; ok {
+		return spec
+	}
+	return titleSorts["votes"]
+}
+
+// orderBySQL return ORDER BY clause untuk sort ini
+func (s titleSortSpec) orderBySQL() string {
+	direction := "ASC"
+	if s.desc {
+		direction = "DESC"
+	}
+	return fmt.Sprintf("ORDER BY %s %s, t.title_id ASC", s.expr, direction)
+}
+
+// ErrInvalidCursor dikembalikan kalau cursor dari client tidak bisa di-decode
+// atau tidak cocok dengan SortBy yang diminta
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// titleCursor adalah isi dari opaque keyset cursor (di-encode base64 JSON)
+// Value adalah sort value dari row terakhir (nil kalau kolomnya NULL), TitleID sebagai tie-breaker
+type titleCursor struct {
+	SortBy  string  `json:"s"`
+	Value   *string `json:"v"`
+	TitleID string  `json:"id"`
+}
+
+// encodeTitleCursor membuat opaque cursor dari row terakhir sebuah page
+func encodeTitleCursor(c titleCursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeTitleCursor membaca cursor dari client dan memastikan SortBy-nya sama
+func decodeTitleCursor(cursor string, sortBy string) (*titleCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c titleCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.TitleID == "" {
+		return nil, ErrInvalidCursor
+	}
+	if c.SortBy != sortBy {
+		return nil, fmt.Errorf("%w: cursor was created for sortBy %q", ErrInvalidCursor, c.SortBy)
+	}
+
+	return &c, nil
+}
+
+// addKeyset menambahkan kondisi "setelah cursor" sesuai arah sort
+// Kolom dibandingkan mentah (tanpa COALESCE) supaya bisa seek, NULL ditangani dengan branch eksplisit:
+//   - DESC (NULL di akhir):  expr < @v OR expr IS NULL OR (expr = @v AND t.title_id > @id)
+//   - ASC (NULL di awal):    expr > @v OR (expr = @v AND t.title_id > @id)
+//   - cursor di row NULL:    (expr IS NULL AND t.title_id > @id), plus "OR expr IS NOT NULL" untuk ASC
+func (q *titleFilterQuery) addKeyset(sort titleSortSpec, c *titleCursor) {
+	id := q.bind(c.TitleID)
+
+	if c.Value == nil {
+		if !sort.nullable {
+			q.add("t.title_id > " + id)
+			return
+		}
+		condition := fmt.Sprintf("(%s IS NULL AND t.title_id > %s)", sort.expr, id)
+		if !sort.desc {
+			condition = fmt.Sprintf("(%s OR %s IS NOT NULL)", condition, sort.expr)
+		}
+		q.add(condition)
+		return
+	}
+
+	op := ">"
+	if sort.desc {
+		op = "<"
+	}
+	value := q.bind(*c.Value)
+	nullBranch := ""
+	if sort.nullable && sort.desc {
+		nullBranch = fmt.Sprintf(" OR %s IS NULL", sort.expr)
+	}
+	q.add(fmt.Sprintf("(%s %s %s%s OR (%s = %s AND t.title_id > %s))",
+		sort.expr, op, value, nullBranch, sort.expr, value, id))
+}
+
+// cleanIDs membuang ID kosong dan duplikat (urutan tetap dipertahankan)
+func cleanIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
+
+// facetSQL membangun query count per option untuk satu facet
+// Lookup table di-LEFT JOIN ke matching titles supaya option dengan count 0 tetap muncul
+// - lookup, idCol, nameCol: lookup table (contoh: genre_types, genre_type_id, genre_name)
+// - join, key: join tambahan dari titles t dan kolom yang di-group (contoh: g.genre_type_id)
+func facetSQL(lookup, idCol, nameCol, join, key string, fq *titleFilterQuery) string {
+	return fmt.Sprintf(`SELECT l.%[2]s, COALESCE(l.%[3]s, l.%[2]s), COUNT(DISTINCT m.title_id)
+	FROM %[1]s l
+	LEFT JOIN (
+		SELECT %[5]s AS facet_id, t.title_id
+		%[6]s%[4]s%[7]s
+	) m ON m.facet_id = l.%[2]s
+	GROUP BY l.%[2]s, l.%[3]s
+	ORDER BY COUNT(DISTINCT m.title_id) DESC, l.%[3]s`,
+		lookup, idCol, nameCol, join, key, fq.fromSQL(), fq.whereSQL())
+}
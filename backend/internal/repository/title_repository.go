@@ -3,7 +3,9 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"film-dashboard-api/internal/models"
@@ -17,77 +19,197 @@ func NewTitleRepository(db *sql.DB) *TitleRepository {
 	return &TitleRepository{db: db}
 }
 
-// GetFilterOptions mengambil semua filter options (genres, types, statuses, years) dari database
+// GetFilterOptions mengambil semua filter options dari database:
+// genres, types, statuses, origin/production countries, spoken languages, networks,
+// production companies (dari *_types tables) dan years (range MIN..MAX startYear di titles)
 func (r *TitleRepository) GetFilterOptions() (*models.FilterOptionsResponse, error) {
 	response := &models.FilterOptionsResponse{
-		Genres:   make([]*models.GenreOption, 0),
-		Types:    make([]*models.TypeOption, 0),
-		Statuses: make([]*models.StatusOption, 0),
-		Years:    make([]int, 0),
+		Genres:              make([]*models.GenreOption, 0),
+		Types:               make([]*models.TypeOption, 0),
+		Statuses:            make([]*models.StatusOption, 0),
+		OriginCountries:     make([]*models.OriginCountryOption, 0),
+		ProductionCountries: make([]*models.ProductionCountryOption, 0),
+		SpokenLanguages:     make([]*models.SpokenLanguageOption, 0),
+		Networks:            make([]*models.NetworkOption, 0),
+		ProductionCompanies: make([]*models.ProductionCompanyOption, 0),
+		Years:               make([]int, 0),
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	// 1. Get Genres
-	fmt.Println("📊 Fetching genres...")
-	genreQuery := `SELECT DISTINCT genre_type_id, genre_name FROM genre_types ORDER BY genre_name`
-	rows, err := r.db.QueryContext(ctx, genreQuery)
-	if err != nil {
-		fmt.Printf("⚠️  Genre Query Error: %v\n", err)
-	} else {
-		defer rows.Close()
-		for rows.Next() {
-			genre := &models.GenreOption{}
-			if err := rows.Scan(&genre.GenreTypeID, &genre.GenreName); err == nil {
-				response.Genres = append(response.Genres, genre)
-			}
-		}
-		fmt.Printf("✅ Found %d genres\n", len(response.Genres))
-	}
+	r.fetchOptions(ctx, "genres",
+		`SELECT DISTINCT genre_type_id, genre_name FROM genre_types WHERE genre_name IS NOT NULL ORDER BY genre_name`,
+		func(id, name string) {
+			response.Genres = append(response.Genres, &models.GenreOption{GenreTypeID: id, GenreName: name})
+		})
 
 	// 2. Get Types
-	fmt.Println("📊 Fetching types...")
-	typeQuery := `SELECT type_id, type_name FROM types ORDER BY type_name`
-	rows, err = r.db.QueryContext(ctx, typeQuery)
-	if err != nil {
-		fmt.Printf("⚠️  Type Query Error: %v\n", err)
-	} else {
-		defer rows.Close()
-		for rows.Next() {
-			typeOpt := &models.TypeOption{}
-			if err := rows.Scan(&typeOpt.TypeID, &typeOpt.TypeName); err == nil {
-				response.Types = append(response.Types, typeOpt)
-			}
-		}
-		fmt.Printf("✅ Found %d types\n", len(response.Types))
-	}
+	r.fetchOptions(ctx, "types",
+		`SELECT type_id, type_name FROM types WHERE type_name IS NOT NULL ORDER BY type_name`,
+		func(id, name string) {
+			response.Types = append(response.Types, &models.TypeOption{TypeID: id, TypeName: name})
+		})
 
 	// 3. Get Statuses
-	fmt.Println("📊 Fetching statuses...")
-	statusQuery := `SELECT status_id, status_name FROM status ORDER BY status_name`
-	rows, err = r.db.QueryContext(ctx, statusQuery)
+	r.fetchOptions(ctx, "statuses",
+		`SELECT status_id, status_name FROM status WHERE status_name IS NOT NULL ORDER BY status_name`,
+		func(id, name string) {
+			response.Statuses = append(response.Statuses, &models.StatusOption{StatusID: id, StatusName: name})
+		})
+
+	// 4. Get Origin Countries
+	r.fetchOptions(ctx, "origin countries",
+		`SELECT origin_country_type_id, origin_country_name FROM origin_country_types
+		WHERE origin_country_name IS NOT NULL ORDER BY origin_country_name`,
+		func(id, name string) {
+			response.OriginCountries = append(response.OriginCountries,
+				&models.OriginCountryOption{OriginCountryTypeID: id, OriginCountryName: name})
+		})
+
+	// 5. Get Production Countries
+	r.fetchOptions(ctx, "production countries",
+		`SELECT production_country_type_id, production_country_name FROM production_country_types
+		WHERE production_country_name IS NOT NULL ORDER BY production_country_name`,
+		func(id, name string) {
+			response.ProductionCountries = append(response.ProductionCountries,
+				&models.ProductionCountryOption{ProductionCountryTypeID: id, ProductionCountryName: name})
+		})
+
+	// 6. Get Spoken Languages (spoken_language_name NVARCHAR(MAX), jadi di-cast untuk ORDER BY)
+	r.fetchOptions(ctx, "spoken languages",
+		`SELECT spoken_language_type_id, spoken_language_name FROM spoken_language_types
+		WHERE spoken_language_name IS NOT NULL ORDER BY CAST(spoken_language_name AS NVARCHAR(200))`,
+		func(id, name string) {
+			response.SpokenLanguages = append(response.SpokenLanguages,
+				&models.SpokenLanguageOption{SpokenLanguageTypeID: id, SpokenLanguageName: name})
+		})
+
+	// 7. Get Networks
+	r.fetchOptions(ctx, "networks",
+		`SELECT network_type_id, network_name FROM network_types
+		WHERE network_name IS NOT NULL ORDER BY network_name`,
+		func(id, name string) {
+			response.Networks = append(response.Networks, &models.NetworkOption{NetworkTypeID: id, NetworkName: name})
+		})
+
+	// 8. Get Production Companies
+	r.fetchOptions(ctx, "production companies",
+		`SELECT production_company_type_id, production_company_name FROM production_company_types
+		WHERE production_company_name IS NOT NULL ORDER BY production_company_name`,
+		func(id, name string) {
+			response.ProductionCompanies = append(response.ProductionCompanies,
+				&models.ProductionCompanyOption{ProductionCompanyTypeID: id, ProductionCompanyName: name})
+		})
+
+	// 9. Get Years (dari MIN..MAX startYear di titles, descending)
+	// Fallback ke 1900..tahun sekarang kalau query gagal atau titles kosong
+	minYear, maxYear := 1900, time.Now().Year()
+	var dbMin, dbMax sql.NullInt64
+	err := r.db.QueryRowContext(ctx, `SELECT MIN(startYear), MAX(startYear) FROM titles`).Scan(&dbMin, &dbMax)
 	if err != nil {
-		fmt.Printf("⚠️  Status Query Error: %v\n", err)
-	} else {
-		defer rows.Close()
+		fmt.Printf("⚠️  Year Range Query Error: %v\n", err)
+	} else if dbMin.Valid && dbMax.Valid {
+		minYear, maxYear = int(dbMin.Int64), int(dbMax.Int64)
+	}
+	for year := maxYear; year >= minYear; year-- {
+		response.Years = append(response.Years, year)
+	}
+	fmt.Printf("✅ Generated %d years (%d-%d)\n", len(response.Years), minYear, maxYear)
+
+	return response, nil
+}
+
+// optionLookup mendefinisikan lookup table untuk satu filter facet (dipakai oleh ResolveOptionIDs)
+type optionLookup struct {
+	table, idCol, nameCol string
+}
+
+// optionLookups mapping nama facet ke lookup table-nya
+// Nama table/kolom hanya dari map ini (tidak pernah dari input user)
+var optionLookups = map[string]optionLookup{
+	"genre":   {"genre_types", "genre_type_id", "genre_name"},
+	"type":    {"types", "type_id", "type_name"},
+	"status":  {"status", "status_id", "status_name"},
+	"country": {"origin_country_types", "origin_country_type_id", "origin_country_name"},
+}
+
+// ResolveOptionIDs mencari ID option berdasarkan nama (atau ID) untuk facet tertentu
+// 1. Exact match ke ID atau nama (case-insensitive sesuai collation database)
+// 2. Kalau tidak ada, partial match ke nama (contoh: "series" -> "tvSeries", "tvMiniSeries")
+// Return slice kosong kalau tidak ada yang match
+func (r *TitleRepository) ResolveOptionIDs(facet string, value string) ([]string, error) {
+	lookup, ok := optionLookups[facet]
+	if !ok {
+		return nil, fmt.Errorf("unknown facet: %s", facet)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	queries := []struct {
+		sql   string
+		param string
+	}{
+		{fmt.Sprintf("SELECT %[2]s FROM %[1]s WHERE %[2]s = @p1 OR %[3]s = @p1", lookup.table, lookup.idCol, lookup.nameCol), value},
+		{fmt.Sprintf("SELECT %[2]s FROM %[1]s WHERE %[3]s LIKE @p1 ESCAPE '\\'", lookup.table, lookup.idCol, lookup.nameCol), "%" + escapeLike(value) + "%"},
+	}
+
+	for _, q := range queries {
+		rows, err := r.db.QueryContext(ctx, q.sql, q.param)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", facet, err)
+		}
+
+		ids := make([]string, 0)
 		for rows.Next() {
-			status := &models.StatusOption{}
-			if err := rows.Scan(&status.StatusID, &status.StatusName); err == nil {
-				response.Statuses = append(response.Statuses, status)
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan %s id: %w", facet, err)
 			}
+			ids = append(ids, id)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error iterating %s ids: %w", facet, err)
+		}
+
+		if len(ids) > 0 {
+			return ids, nil
 		}
-		fmt.Printf("✅ Found %d statuses\n", len(response.Statuses))
 	}
 
-	// 4. Get Years (from 1900 to current year, descending)
-	currentYear := time.Now().Year()
-	for year := currentYear; year >= 1900; year-- {
-		response.Years = append(response.Years, year)
+	return []string{}, nil
+}
+
+// escapeLike escape wildcard LIKE (%, _, [) supaya input user dicari sebagai literal
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`, "[", `\[`).Replace(value)
+}
+
+// fetchOptions menjalankan lookup query (2 kolom: id, name) dan memanggil add untuk setiap row
+// Error di-log saja (sama seperti sebelumnya) supaya satu lookup yang gagal tidak menggagalkan semua options
+func (r *TitleRepository) fetchOptions(ctx context.Context, label string, query string, add func(id, name string)) {
+	fmt.Printf("📊 Fetching %s...\n", label)
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		fmt.Printf("⚠️  %s Query Error: %v\n", label, err)
+		return
 	}
-	fmt.Printf("✅ Generated %d years\n", len(response.Years))
+	defer rows.Close()
 
-	return response, nil
+	count := 0
+	for rows.Next() {
+		var id, name string
+		if err := rows.Scan(&id, &name); err == nil {
+			add(id, name)
+			count++
+		}
+	}
+	fmt.Printf("✅ Found %d %s\n", count, label)
 }
 
 func (r *TitleRepository) GetTrendingTitles(limit int) ([]*models.TrendingTitle, error) {
@@ -131,6 +253,11 @@ func (r *TitleRepository) GetTrendingTitles(limit int) ([]*models.TrendingTitle,
 		return nil, fmt.Errorf("error iterating rows: %w", err)
 	}
 
+	// Stored procedure tidak return community score, ambil terpisah
+	if err := r.fillCommunityScores(ctx, titles); err != nil {
+		return nil, err
+	}
+
 	return titles, nil
 }
 
@@ -175,392 +302,253 @@ func (r *TitleRepository) GetTopRatedTitles(limit int) ([]*models.FilmCardData,
 		return nil, fmt.Errorf("error iterating rows: %w", err)
 	}
 
+	// Stored procedure tidak return community score, ambil terpisah
+	if err := r.fillCommunityScores(ctx, titles); err != nil {
+		return nil, err
+	}
+
 	return titles, nil
 }
 
-// SearchTitles mencari titles berdasarkan keyword menggunakan sp_SearchTitles
-// Parameter: keyword (search term)
-// Return: slice dari FilmCardData dan error (kalau ada)
-func (r *TitleRepository) SearchTitles(keyword string) ([]*models.FilmCardData, error) {
-	query := `EXEC sp_SearchTitles @keyword = @p1`
-
-	// Query returns multiple rows
+// GetAlternateTitles mengambil judul alternatif sebuah title
+// region dan language optional (string kosong = semua), contoh: region "ID", language "id"
+// Return nil (tanpa error) kalau title tidak ditemukan
+func (r *TitleRepository) GetAlternateTitles(titleID string, region string, language string) ([]*models.AlternateTitle, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
-	rows, err := r.db.QueryContext(ctx, query, keyword)
+
+	exists, err := r.titleExists(ctx, titleID)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return r.queryAlternateTitles(ctx, titleID, region, language)
+}
+
+// queryAlternateTitles menjalankan query alternate_titles dengan filter region/language optional
+// Original title selalu di urutan pertama, lalu berdasarkan ordering
+func (r *TitleRepository) queryAlternateTitles(ctx context.Context, titleID string, region string, language string) ([]*models.AlternateTitle, error) {
+	query := `SELECT at.title, at.region, at.language, at.types, at.attributes, at.isOriginalTitle
+    FROM alternate_titles at
+    WHERE at.title_id = @p1
+      AND (@p2 = N'' OR at.region = @p2)
+      AND (@p3 = N'' OR at.language = @p3)
+    ORDER BY COALESCE(at.isOriginalTitle, 0) DESC, at.ordering`
+
+	rows, err := r.db.QueryContext(ctx, query, titleID, region, language)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute search query: %w", err)
+		return nil, fmt.Errorf("failed to get alternate titles: %w", err)
 	}
 	defer rows.Close()
 
-	// Slice untuk store results
-	var titles []*models.FilmCardData
-
-	// Loop through rows
+	titles := make([]*models.AlternateTitle, 0)
 	for rows.Next() {
-		var title models.FilmCardData
-
-		// Scan each row (6 columns from fnGetFilmCardDetail)
-		err := rows.Scan(
-			&title.TitleID,
-			&title.Name,
-			&title.StartYear,
-			&title.VoteAverage,
-			&title.VoteCount,
-			&title.GenreName,
-		)
+		alt := &models.AlternateTitle{}
+		err := rows.Scan(&alt.Title, &alt.Region, &alt.Language, &alt.Types, &alt.Attributes, &alt.IsOriginalTitle)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
+			return nil, fmt.Errorf("failed to scan alternate title: %w", err)
 		}
-
-		// Append ke slice
-		titles = append(titles, &title)
+		titles = append(titles, alt)
 	}
-
-	// Check error dari rows iteration
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating rows: %w", err)
+		return nil, fmt.Errorf("error iterating alternate titles: %w", err)
 	}
+
 	return titles, nil
 }
 
-// GetTitleDetail mengambil detail lengkap title berdasarkan title_id menggunakan 8 query terpisah
-// Setiap data diambil dengan query terpisah untuk compatibility dengan driver go-mssqldb
-// Return: TitleDetailResponse dan error (kalau ada)
-func (r *TitleRepository) GetTitleDetail(titleID string) (*models.TitleDetailResponse, error) {
-	fmt.Printf("\n📖 Getting title detail for: '%s'\n", titleID)
-
-	response := &models.TitleDetailResponse{
-		Genres:      make([]*models.Genre, 0),
-		Languages:   make([]*models.Language, 0),
-		Countries:   make([]*models.ProductionCountry, 0),
-		Companies:   make([]*models.ProductionCompany, 0),
-		Networks:    make([]*models.Network, 0),
-		AirDates:    make([]*models.AirDate, 0),
-		CastAndCrew: make([]*models.CastCrew, 0),
-	}
-
+// GetSeasons mengambil daftar season dari sebuah series beserta jumlah episode,
+// range air date, dan rata-rata rating episode
+// Return nil (tanpa error) kalau series tidak ditemukan
+func (r *TitleRepository) GetSeasons(seriesID string) ([]*models.Season, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// 1. Get title detail
-	fmt.Println("📊 QUERY 1: Title Detail")
-	query1 := `SELECT 
-        t.title_id,
-        t.name,
-        t.original_name,
-        t.overview,
-        t.popularity,
-        t.vote_average,
-        t.vote_count,
-        t.runtimeMinutes,
-        t.startYear,
-        t.endYear,
-        t.number_of_seasons,
-        t.number_of_episodes,
-        ty.type_name,
-        s.status_name,
-        t.tagline
-    FROM titles t
-    LEFT JOIN types ty ON t.type_id = ty.type_id
-    LEFT JOIN status s ON t.status_id = s.status_id
-    WHERE t.title_id = @p1`
-
-	rows, err := r.db.QueryContext(ctx, query1, titleID)
+	exists, err := r.titleExists(ctx, seriesID)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	query := `SELECT
+		e.seasonNumber,
+		COUNT(*) AS episode_count,
+		CONVERT(NVARCHAR(10), MIN(ad.air_date), 23) AS first_air_date,
+		CONVERT(NVARCHAR(10), MAX(ad.air_date), 23) AS last_air_date,
+		CAST(ROUND(AVG(CAST(t.vote_average AS FLOAT)), 1) AS FLOAT) AS average_rating
+	FROM episodes e
+	LEFT JOIN titles t ON t.title_id = e.title_id
+	OUTER APPLY (SELECT MIN(a.date) AS air_date FROM air_dates a WHERE a.title_id = e.title_id) ad
+	WHERE e.parent_title_id = @p1
+	GROUP BY e.seasonNumber
+	ORDER BY e.seasonNumber`
+
+	rows, err := r.db.QueryContext(ctx, query, seriesID)
 	if err != nil {
-		fmt.Printf("❌ Query 1 Error: %v\n", err)
-		return nil, fmt.Errorf("failed to execute query 1: %w", err)
+		return nil, fmt.Errorf("failed to get seasons: %w", err)
 	}
 	defer rows.Close()
 
-	if rows.Next() {
-		detail := &models.TitleDetail{}
+	seasons := make([]*models.Season, 0)
+	for rows.Next() {
+		season := &models.Season{}
 		err := rows.Scan(
-			&detail.TitleID,
-			&detail.Name,
-			&detail.OriginalName,
-			&detail.Overview,
-			&detail.Popularity,
-			&detail.VoteAverage,
-			&detail.VoteCount,
-			&detail.RuntimeMinutes,
-			&detail.StartYear,
-			&detail.EndYear,
-			&detail.NumberSeasons,
-			&detail.NumberEpisodes,
-			&detail.Type,
-			&detail.Status,
-			&detail.Tagline,
+			&season.SeasonNumber,
+			&season.EpisodeCount,
+			&season.FirstAirDate,
+			&season.LastAirDate,
+			&season.AverageRating,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan title detail: %w", err)
-		}
-		response.Detail = detail
-		fmt.Printf("✅ Got title: %s (ID: %s)\n", *detail.Name, *detail.TitleID)
-	} else {
-		fmt.Println("⚠️  No title detail found")
-		return response, nil
-	}
-	rows.Close()
-	// 2. Get genres
-	fmt.Println("📊 QUERY 2: Genres")
-	query2 := `SELECT gt.genre_name
-    FROM genres g
-    JOIN genre_types gt ON g.genre_type_id = gt.genre_type_id
-    WHERE g.title_id = @p1`
-
-	rows, err = r.db.QueryContext(ctx, query2, titleID)
-	if err != nil {
-		fmt.Printf("⚠️  Genres Query Error: %v\n", err)
-	} else {
-		defer rows.Close()
-		genreCount := 0
-		for rows.Next() {
-			genre := &models.Genre{}
-			err := rows.Scan(&genre.GenreName)
-			if err == nil {
-				response.Genres = append(response.Genres, genre)
-				genreCount++
-			}
+			return nil, fmt.Errorf("failed to scan season: %w", err)
 		}
-		fmt.Printf("✅ Found %d genres\n", genreCount)
+		seasons = append(seasons, season)
 	}
-
-	// 3. Get languages
-	fmt.Println("📊 QUERY 3: Languages")
-	query3 := `SELECT lt.language_name
-    FROM languages l
-    JOIN language_types lt ON l.language_type_id = lt.language_type_id
-    WHERE l.title_id = @p1`
-
-	rows, err = r.db.QueryContext(ctx, query3, titleID)
-	if err != nil {
-		fmt.Printf("⚠️  Languages Query Error: %v\n", err)
-	} else {
-		defer rows.Close()
-		langCount := 0
-		for rows.Next() {
-			lang := &models.Language{}
-			err := rows.Scan(&lang.LanguageName)
-			if err == nil {
-				response.Languages = append(response.Languages, lang)
-				langCount++
-			}
-		}
-		fmt.Printf("✅ Found %d languages\n", langCount)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating seasons: %w", err)
 	}
 
-	// 4. Get production countries
-	fmt.Println("📊 QUERY 4: Production Countries")
-	query4 := `SELECT pct.production_country_name
-    FROM production_countries pc
-    JOIN production_country_types pct ON pc.production_country_type_id = pct.production_country_type_id
-    WHERE pc.title_id = @p1`
-
-	rows, err = r.db.QueryContext(ctx, query4, titleID)
-	if err != nil {
-		fmt.Printf("⚠️  Countries Query Error: %v\n", err)
-	} else {
-		defer rows.Close()
-		countryCount := 0
-		for rows.Next() {
-			country := &models.ProductionCountry{}
-			err := rows.Scan(&country.CountryName)
-			if err == nil {
-				response.Countries = append(response.Countries, country)
-				countryCount++
-			}
-		}
-		fmt.Printf("✅ Found %d countries\n", countryCount)
-	}
+	return seasons, nil
+}
 
-	// 5. Get production companies
-	fmt.Println("📊 QUERY 5: Production Companies")
-	query5 := `SELECT pct.production_company_name
-    FROM production_companies pc
-    JOIN production_company_types pct ON pc.production_company_type_id = pct.production_company_type_id
-    WHERE pc.title_id = @p1`
+// GetSeasonEpisodes mengambil semua episode di satu season (urut berdasarkan episodeNumber)
+// Return nil (tanpa error) kalau series tidak ditemukan
+func (r *TitleRepository) GetSeasonEpisodes(seriesID string, seasonNumber int) ([]*models.Episode, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
-	rows, err = r.db.QueryContext(ctx, query5, titleID)
+	exists, err := r.titleExists(ctx, seriesID)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	query := `SELECT
+		e.title_id,
+		t.name,
+		e.seasonNumber,
+		e.episodeNumber,
+		CONVERT(NVARCHAR(10), ad.air_date, 23) AS air_date,
+		t.vote_average,
+		t.vote_count,
+		t.runtimeMinutes
+	FROM episodes e
+	LEFT JOIN titles t ON t.title_id = e.title_id
+	OUTER APPLY (SELECT MIN(a.date) AS air_date FROM air_dates a WHERE a.title_id = e.title_id) ad
+	WHERE e.parent_title_id = @p1 AND e.seasonNumber = @p2
+	ORDER BY e.episodeNumber, e.title_id`
+
+	rows, err := r.db.QueryContext(ctx, query, seriesID, seasonNumber)
 	if err != nil {
-		fmt.Printf("⚠️  Companies Query Error: %v\n", err)
-	} else {
-		defer rows.Close()
-		companyCount := 0
-		for rows.Next() {
-			company := &models.ProductionCompany{}
-			err := rows.Scan(&company.CompanyName)
-			if err == nil {
-				response.Companies = append(response.Companies, company)
-				companyCount++
-			}
-		}
-		fmt.Printf("✅ Found %d companies\n", companyCount)
+		return nil, fmt.Errorf("failed to get episodes: %w", err)
 	}
+	defer rows.Close()
 
-	// 6. Get networks
-	fmt.Println("📊 QUERY 6: Networks")
-	query6 := `SELECT nt.network_name
-    FROM networks n
-    JOIN network_types nt ON n.network_type_id = nt.network_type_id
-    WHERE n.title_id = @p1`
-
-	rows, err = r.db.QueryContext(ctx, query6, titleID)
-	if err != nil {
-		fmt.Printf("⚠️  Networks Query Error: %v\n", err)
-	} else {
-		defer rows.Close()
-		networkCount := 0
-		for rows.Next() {
-			network := &models.Network{}
-			err := rows.Scan(&network.NetworkName)
-			if err == nil {
-				response.Networks = append(response.Networks, network)
-				networkCount++
-			}
+	episodes := make([]*models.Episode, 0)
+	for rows.Next() {
+		episode := &models.Episode{}
+		err := rows.Scan(
+			&episode.TitleID,
+			&episode.Name,
+			&episode.SeasonNumber,
+			&episode.EpisodeNumber,
+			&episode.AirDate,
+			&episode.VoteAverage,
+			&episode.VoteCount,
+			&episode.RuntimeMinutes,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan episode: %w", err)
 		}
-		fmt.Printf("✅ Found %d networks\n", networkCount)
+		episodes = append(episodes, episode)
 	}
-
-	// 7. Get air dates
-	fmt.Println("📊 QUERY 7: Air Dates")
-	query7 := `SELECT a.date, a.is_first, e.seasonNumber, e.episodeNumber
-    FROM air_dates a
-    LEFT JOIN episodes e ON a.title_id = e.title_id
-    WHERE a.title_id = @p1`
-
-	rows, err = r.db.QueryContext(ctx, query7, titleID)
-	if err != nil {
-		fmt.Printf("⚠️  Air Dates Query Error: %v\n", err)
-	} else {
-		defer rows.Close()
-		airDateCount := 0
-		for rows.Next() {
-			airDate := &models.AirDate{}
-			err := rows.Scan(
-				&airDate.Date,
-				&airDate.IsFirst,
-				&airDate.SeasonNumber,
-				&airDate.EpisodeNumber,
-			)
-			if err == nil {
-				response.AirDates = append(response.AirDates, airDate)
-				airDateCount++
-			}
-		}
-		fmt.Printf("✅ Found %d air dates\n", airDateCount)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating episodes: %w", err)
 	}
 
-	// 8. Get cast and crew
-	fmt.Println("📊 QUERY 8: Cast & Crew")
-	query8 := `SELECT tp.ordering, p.primaryName AS person_name, tp.category AS job_category, tp.characters
-    FROM title_principals tp
-    JOIN persons p ON tp.person_id = p.person_id
-    WHERE tp.title_id = @p1
-    ORDER BY tp.ordering`
+	return episodes, nil
+}
+
+// ErrTitleNotFound dikembalikan kalau title_id tidak ada di table titles
+var ErrTitleNotFound = errors.New("title not found")
 
-	rows, err = r.db.QueryContext(ctx, query8, titleID)
+// titleExists mengecek apakah title_id ada di table titles
+func (r *TitleRepository) titleExists(ctx context.Context, titleID string) (bool, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM titles WHERE title_id = @p1`, titleID).Scan(&count)
 	if err != nil {
-		fmt.Printf("⚠️  Cast/Crew Query Error: %v\n", err)
-	} else {
-		defer rows.Close()
-		castCount := 0
-		for rows.Next() {
-			castCrew := &models.CastCrew{}
-			err := rows.Scan(
-				&castCrew.Ordering,
-				&castCrew.PersonName,
-				&castCrew.JobCategory,
-				&castCrew.Characters,
-			)
-			if err == nil {
-				response.CastAndCrew = append(response.CastAndCrew, castCrew)
-				castCount++
-			}
-		}
-		fmt.Printf("✅ Found %d cast/crew members\n\n", castCount)
+		return false, fmt.Errorf("failed to check title: %w", err)
 	}
-
-	return response, nil
+	return count > 0, nil
 }
 
-// FilterTitles melakukan filter titles berdasarkan berbagai parameter menggunakan sp_filter_titles
+// FilterTitles melakukan filter titles berdasarkan FilterRequest
 // Supports multiple selections untuk Genre, Type, Status, dan Countries
-// Pagination handled in SP using Page and Limit parameters
-func (r *TitleRepository) FilterTitles(
-	genreIDs []string,
-	typeIDs []string,
-	statusIDs []string,
-	originCountryIDs []string,
-	productionCountryIDs []string,
-	year *int,
-	sortBy string,
-	offset int,
-	limit int,
-) ([]*models.FilmCardData, int, error) {
-	// Calculate page from offset and limit
-	// SP expects: Page parameter (1-based), not offset
-	// offset = (page - 1) * limit, so page = (offset / limit) + 1
-	page := (offset / limit) + 1
+// (OR di dalam satu facet, AND antar facet - lihat newTitleFilterQuery)
+// Kalau filter.Q diisi, hasil dibatasi ke titles yang match full-text search
+// (dipakai oleh /api/titles/search, SortBy "relevance" = rank full-text)
+// Pagination:
+// - Offset mode (default): pakai Page & Limit
+// - Keyset mode: kalau filter.Cursor diisi, ambil rows setelah cursor
+//   (Page diabaikan, PaginationInfo.Page/TotalPage tidak dikirim)
+// Return: titles untuk page yang diminta, PaginationInfo (total exact + nextCursor), dan error
+func (r *TitleRepository) FilterTitles(filter *models.FilterRequest) ([]*models.FilmCardData, *models.PaginationInfo, error) {
+	page := filter.Page
 	if page < 1 {
 		page = 1
 	}
+	offset := (page - 1) * filter.Limit
+	sort := titleSort(filter.SortBy)
 
-	// Convert slices to pointers (use first value if multiple, or nil if empty)
-	var genreID, typeID, statusID, originCountryID, productionCountryID *string
-	if len(genreIDs) > 0 {
-		genreID = &genreIDs[0]
-	}
-	if len(typeIDs) > 0 {
-		typeID = &typeIDs[0]
-	}
-	if len(statusIDs) > 0 {
-		statusID = &statusIDs[0]
-	}
-	if len(originCountryIDs) > 0 {
-		originCountryID = &originCountryIDs[0]
-	}
-	if len(productionCountryIDs) > 0 {
-		productionCountryID = &productionCountryIDs[0]
-	}
-
-	query := `EXEC sp_filter_titles_filmcard 
-		@GenreId = @p1,
-		@TypeId = @p2,
-		@StatusId = @p3,
-		@OriginCountryId = @p4,
-		@ProductionCountryId = @p5,
-		@Year = @p6,
-		@SortBy = @p7,
-		@Page = @p8,
-		@Limit = @p9`
+	fq := newTitleFilterQuery(filter)
+	if filter.Cursor != "" {
+		cursor, err := decodeTitleCursor(filter.Cursor, filter.SortBy)
+		if err != nil {
+			return nil, nil, err
+		}
+		fq.addKeyset(sort, cursor)
+		offset = 0
+	}
+
+	// Ambil limit+1 rows untuk tahu apakah masih ada page berikutnya
+	query := fmt.Sprintf(`SELECT
+		f.title_id,
+		f.name,
+		f.startYear,
+		f.vote_average,
+		f.vote_count,
+		f.genre_name,
+		cs.community_score,
+		%s AS sort_value
+	%s
+	CROSS APPLY dbo.fnGetFilmCardDetail(t.title_id) f%s
+	%s
+	OFFSET %s ROWS FETCH NEXT %s ROWS ONLY`,
+		sort.expr, fq.fromSQL(), fq.whereSQL(), sort.orderBySQL(), fq.bind(offset), fq.bind(filter.Limit+1))
 
 	fmt.Println("\n=== FILTER REQUEST ===")
-	fmt.Printf("GenreIDs: %v (%d items), TypeIDs: %v (%d items), StatusIDs: %v (%d items)\n", 
-		genreIDs, len(genreIDs), typeIDs, len(typeIDs), statusIDs, len(statusIDs))
-	fmt.Printf("OriginCountryIDs: %v, ProductionCountryIDs: %v, Year: %v\n", originCountryIDs, productionCountryIDs, year)
-	fmt.Printf("SortBy: %s, Page: %d, Limit: %d\n", sortBy, page, limit)
+	fmt.Printf("GenreIDs: %v (%d items), TypeIDs: %v (%d items), StatusIDs: %v (%d items)\n",
+		filter.GenreIDs, len(filter.GenreIDs), filter.TypeIDs, len(filter.TypeIDs), filter.StatusIDs, len(filter.StatusIDs))
+	fmt.Printf("OriginCountryIDs: %v, ProductionCountryIDs: %v, Year: %v\n", filter.OriginCountryIDs, filter.ProductionCountryIDs, filter.Year)
+	fmt.Printf("Q: %q, SortBy: %s, Page: %d, Limit: %d, Cursor: %q\n", filter.Q, filter.SortBy, page, filter.Limit, filter.Cursor)
 
-	// Execute query with pagination handled in SP
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
-	rows, err := r.db.QueryContext(ctx, query, genreID, typeID, statusID, originCountryID, productionCountryID, year, sortBy, page, limit)
+	rows, err := r.db.QueryContext(ctx, query, fq.params...)
 	if err != nil {
 		fmt.Printf("❌ Database Query Error: %v\n", err)
-		return nil, 0, fmt.Errorf("failed to execute filter query: %w", err)
+		return nil, nil, fmt.Errorf("failed to execute filter query: %w", err)
 	}
 	defer rows.Close()
 
-	fmt.Println("✅ Query executed successfully")
-
-	// Slice untuk store results (will contain exactly `limit` rows or fewer for last page)
+	// Slice untuk store results (maksimal limit+1 rows)
 	var titles []*models.FilmCardData
+	var sortValues []*string
 
 	// Loop through rows
 	for rows.Next() {
 		var title models.FilmCardData
+		var sortValue *string
 
-		// Scan each row (6 columns from fnGetFilmCardDetail)
+		// Scan each row (6 columns from fnGetFilmCardDetail + community score + sort value untuk cursor)
 		err := rows.Scan(
 			&title.TitleID,
 			&title.Name,
@@ -568,113 +556,188 @@ func (r *TitleRepository) FilterTitles(
 			&title.VoteAverage,
 			&title.VoteCount,
 			&title.GenreName,
+			&title.CommunityScore,
+			&sortValue,
 		)
 		if err != nil {
 			fmt.Printf("❌ Scan Error: %v\n", err)
-			return nil, 0, fmt.Errorf("failed to scan row: %w", err)
+			return nil, nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 
 		// Append ke slice
 		titles = append(titles, &title)
-		ratingStr := fmt.Sprintf("%.1f", title.VoteAverage)
-		fmt.Printf("✅ Found: %s (Rating: %s, Year: %d, Genre: %s)\n", title.Name, ratingStr, title.StartYear, title.GenreName)
+		sortValues = append(sortValues, sortValue)
 	}
 
 	// Check error dari rows iteration
 	if err = rows.Err(); err != nil {
 		fmt.Printf("❌ Rows Iteration Error: %v\n", err)
-		return nil, 0, fmt.Errorf("error iterating rows: %w", err)
+		return nil, nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	pagination := &models.PaginationInfo{
+		Limit: filter.Limit,
+	}
+	if filter.Cursor == "" {
+		pagination.Page = page
+	}
+
+	// Row ke limit+1 hanya penanda ada page berikutnya, tidak dikirim ke client
+	if len(titles) > filter.Limit {
+		titles = titles[:filter.Limit]
+		last := titles[len(titles)-1]
+		pagination.HasNext = true
+		pagination.NextCursor = encodeTitleCursor(titleCursor{
+			SortBy:  filter.SortBy,
+			Value:   sortValues[len(titles)-1],
+			TitleID: last.TitleID,
+		})
 	}
 
 	// Get total count - need to query separately to get accurate total
-	totalCount, err := r.GetFilterTitlesCount(genreID, typeID, statusID, originCountryID, productionCountryID, year)
+	// Di keyset mode tidak ada fallback: offset tidak diketahui, jadi total dari page ini pasti salah
+	totalCount, err := r.GetFilterTitlesCount(filter)
 	if err != nil {
+		if filter.Cursor != "" {
+			return nil, nil, err
+		}
 		fmt.Printf("⚠️  Warning: Could not get total count: %v\n", err)
-		totalCount = len(titles) // Fallback to returned count
+		totalCount = offset + len(titles) // Fallback to returned count
+	}
+	pagination.Total = totalCount
+	if filter.Cursor == "" {
+		pagination.TotalPage = (totalCount + filter.Limit - 1) / filter.Limit
 	}
 
 	fmt.Printf("📊 Total matching titles: %d\n", totalCount)
-	fmt.Printf("📊 Returned (page %d): %d\n", page, len(titles))
-	fmt.Println("====================\n")
+	fmt.Printf("📊 Returned (page %d): %d, hasNext: %v\n", page, len(titles), pagination.HasNext)
+	fmt.Println("====================")
 
-	return titles, totalCount, nil
+	return titles, pagination, nil
 }
 
-// GetFilterTitlesCount menghitung total matching titles (untuk pagination info)
-// Matches the same filters as sp_filter_titles
-func (r *TitleRepository) GetFilterTitlesCount(
-	genreID *string,
-	typeID *string,
-	statusID *string,
-	originCountryID *string,
-	productionCountryID *string,
-	year *int,
-) (int, error) {
-	// Build query with proper parameter handling
-	query := `SELECT COUNT(*) FROM (
-		SELECT TOP 1000 t.title_id
-		FROM titles t`
-
-	where := " WHERE 1=1"
-	var params []interface{}
-	paramNum := 1
-
-	// Add joins based on which filters are active
-	if genreID != nil {
-		query += " JOIN genres g ON g.title_id = t.title_id"
-	}
-	if originCountryID != nil {
-		query += " JOIN production_countries oc ON oc.title_id = t.title_id"
-	}
-	if productionCountryID != nil {
-		query += " JOIN production_countries pc ON pc.title_id = t.title_id"
-	}
-
-	// Add WHERE conditions with proper parameter binding
-	if genreID != nil {
-		where += fmt.Sprintf(" AND g.genre_type_id = @p%d", paramNum)
-		params = append(params, genreID)
-		paramNum++
-	}
-	if typeID != nil {
-		where += fmt.Sprintf(" AND t.type_id = @p%d", paramNum)
-		params = append(params, typeID)
-		paramNum++
-	}
-	if statusID != nil {
-		where += fmt.Sprintf(" AND t.status_id = @p%d", paramNum)
-		params = append(params, statusID)
-		paramNum++
-	}
-	if originCountryID != nil {
-		where += fmt.Sprintf(" AND oc.origin_country_type_id = @p%d", paramNum)
-		params = append(params, originCountryID)
-		paramNum++
-	}
-	if productionCountryID != nil {
-		where += fmt.Sprintf(" AND pc.production_country_type_id = @p%d", paramNum)
-		params = append(params, productionCountryID)
-		paramNum++
-	}
-	if year != nil {
-		where += fmt.Sprintf(" AND t.startYear = @p%d", paramNum)
-		params = append(params, year)
-		paramNum++
-	}
-
-	query += where + ") AS cnt"
+// GetFilterTitlesCount menghitung total exact matching titles (untuk pagination info)
+// Memakai titleFilterQuery yang sama dengan FilterTitles, jadi hasilnya selalu konsisten
+// Cursor tidak ikut dihitung - total selalu untuk seluruh filter set
+func (r *TitleRepository) GetFilterTitlesCount(filter *models.FilterRequest) (int, error) {
+	fq := newTitleFilterQuery(filter)
+	query := fmt.Sprintf(`SELECT COUNT(*)
+	%s%s`, fq.fromSQL(), fq.whereSQL())
 
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
 
 	var count int
-	err := r.db.QueryRowContext(ctx, query, params...).Scan(&count)
+	err := r.db.QueryRowContext(ctx, query, fq.params...).Scan(&count)
 	if err != nil {
 		fmt.Printf("❌ Count Query Error: %v\n", err)
 		fmt.Printf("📝 Query: %s\n", query)
-		fmt.Printf("📝 Params: %v\n", params)
+		fmt.Printf("📝 Params: %v\n", fq.params)
 		return 0, fmt.Errorf("failed to get filter titles count: %w", err)
 	}
 
 	return count, nil
 }
+
+// GetFilterFacets menghitung jumlah matching titles per option untuk setiap facet
+// (genre, type, status, origin country, decade) berdasarkan filter yang sedang aktif
+// Setiap facet mengabaikan filter miliknya sendiri - contoh: facet genre dihitung
+// dengan filter type/status/dll aktif, tapi tanpa filter genre
+func (r *TitleRepository) GetFilterFacets(filter *models.FilterRequest) (*models.FilterFacets, error) {
+	facets := &models.FilterFacets{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// 1. Genres
+	without := *filter
+	without.GenreIDs = nil
+	fq := newTitleFilterQuery(&without)
+	genres, err := r.queryFacet(ctx, facetSQL("genre_types", "genre_type_id", "genre_name",
+		"\n\t\tJOIN genres g ON g.title_id = t.title_id", "g.genre_type_id", fq), fq.params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count genre facet: %w", err)
+	}
+	facets.Genres = genres
+
+	// 2. Types
+	without = *filter
+	without.TypeIDs = nil
+	fq = newTitleFilterQuery(&without)
+	types, err := r.queryFacet(ctx, facetSQL("types", "type_id", "type_name", "", "t.type_id", fq), fq.params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count type facet: %w", err)
+	}
+	facets.Types = types
+
+	// 3. Statuses
+	without = *filter
+	without.StatusIDs = nil
+	fq = newTitleFilterQuery(&without)
+	statuses, err := r.queryFacet(ctx, facetSQL("status", "status_id", "status_name", "", "t.status_id", fq), fq.params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count status facet: %w", err)
+	}
+	facets.Statuses = statuses
+
+	// 4. Origin countries
+	without = *filter
+	without.OriginCountryIDs = nil
+	fq = newTitleFilterQuery(&without)
+	countries, err := r.queryFacet(ctx, facetSQL("origin_country_types", "origin_country_type_id", "origin_country_name",
+		"\n\t\tJOIN production_countries pc ON pc.title_id = t.title_id", "pc.origin_country_type_id", fq), fq.params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count origin country facet: %w", err)
+	}
+	facets.OriginCountries = countries
+
+	// 5. Decades (tanpa filter year / yearFrom / yearTo)
+	without = *filter
+	without.Year, without.YearFrom, without.YearTo = nil, nil, nil
+	fq = newTitleFilterQuery(&without)
+	fq.add("t.startYear IS NOT NULL")
+	decadeQuery := fmt.Sprintf(`SELECT (t.startYear / 10) * 10 AS decade, COUNT(*)
+	%s%s
+	GROUP BY (t.startYear / 10) * 10
+	ORDER BY decade DESC`, fq.fromSQL(), fq.whereSQL())
+
+	rows, err := r.db.QueryContext(ctx, decadeQuery, fq.params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count decade facet: %w", err)
+	}
+	defer rows.Close()
+
+	facets.Decades = make([]*models.DecadeCount, 0)
+	for rows.Next() {
+		decade := &models.DecadeCount{}
+		if err := rows.Scan(&decade.Decade, &decade.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan decade facet: %w", err)
+		}
+		facets.Decades = append(facets.Decades, decade)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating decade facet: %w", err)
+	}
+
+	return facets, nil
+}
+
+// queryFacet menjalankan query dari facetSQL dan scan hasilnya ke FacetCount
+func (r *TitleRepository) queryFacet(ctx context.Context, query string, params []interface{}) ([]*models.FacetCount, error) {
+	rows, err := r.db.QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make([]*models.FacetCount, 0)
+	for rows.Next() {
+		count := &models.FacetCount{}
+		if err := rows.Scan(&count.ID, &count.Name, &count.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+
+	return counts, rows.Err()
+}
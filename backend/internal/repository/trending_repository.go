@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"film-dashboard-api/internal/models"
+)
+
+// Bobot tiap signal trending (review paling "mahal", detail view paling murah)
+const (
+	trendingReviewWeight    = 3.0
+	trendingWatchlistWeight = 2.0
+	trendingViewWeight      = 1.0
+)
+
+// RefreshTrendingScores menghitung ulang TrendingScores untuk satu window
+// Semua aktivitas di dalam window dijumlahkan dengan exponential time decay (dihitung sebagai FLOAT:
+// POWER mengikuti tipe argumen pertama, literal 0.5 akan membulatkan decay ke 1 desimal), lalu
+// hasil lama window tersebut diganti dalam satu transaction (reader tidak melihat tabel kosong)
+// Return jumlah title yang punya aktivitas
+func (r *TitleRepository) RefreshTrendingScores(window models.TrendingWindow) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	query := `
+		SET XACT_ABORT ON;
+		DECLARE @now DATETIME = GETDATE();
+		DECLARE @since DATETIME = DATEADD(HOUR, -@p2, @now);
+		DECLARE @count INT;
+
+		BEGIN TRANSACTION;
+
+		DELETE FROM TrendingScores WHERE time_window = @p1;
+
+		INSERT INTO TrendingScores (time_window, title_id, score, review_count, watchlist_count, view_count, computed_at)
+		SELECT
+			@p1,
+			a.title_id,
+			SUM(a.weight * POWER(CAST(0.5 AS FLOAT), DATEDIFF(MINUTE, a.happened_at, @now) / (60.0 * @p3))),
+			SUM(CASE WHEN a.kind = 'review' THEN 1 ELSE 0 END),
+			SUM(CASE WHEN a.kind = 'watchlist' THEN 1 ELSE 0 END),
+			SUM(CASE WHEN a.kind = 'view' THEN 1 ELSE 0 END),
+			@now
+		FROM (
+			SELECT title_id, created_at AS happened_at, 'review' AS kind, @p4 AS weight
+			FROM Reviews
+			WHERE created_at >= @since AND is_hidden = 0
+			UNION ALL
+			SELECT title_id, added_at, 'watchlist', @p5
+			FROM Watchlist
+			WHERE added_at >= @since
+			UNION ALL
+			SELECT title_id, viewed_at, 'view', @p6
+			FROM TitleViews
+			WHERE viewed_at >= @since
+		) a
+		GROUP BY a.title_id;
+
+		SET @count = @@ROWCOUNT;
+
+		COMMIT TRANSACTION;
+
+		SELECT @count;
+	`
+
+	var count int64
+	err := r.db.QueryRowContext(ctx, query, window.Name, window.Hours, window.HalfLifeHours,
+		trendingReviewWeight, trendingWatchlistWeight, trendingViewWeight).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to refresh trending scores (%s): %w", window.Name, err)
+	}
+	return count, nil
+}
+
+// PruneTitleViews menghapus TitleViews yang lebih tua dari retentionHours
+// Dihapus per batch supaya tidak menahan lock lama di table yang terus di-insert
+// Return jumlah row yang dihapus
+func (r *TitleRepository) PruneTitleViews(retentionHours int) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	query := `
+		DECLARE @cutoff DATETIME = DATEADD(HOUR, -@p1, GETDATE());
+		DECLARE @deleted INT = 0;
+		DECLARE @batch INT = 1;
+
+		WHILE @batch > 0
+		BEGIN
+			DELETE TOP (5000) FROM TitleViews WHERE viewed_at < @cutoff;
+			SET @batch = @@ROWCOUNT;
+			SET @deleted = @deleted + @batch;
+		END
+
+		SELECT @deleted;
+	`
+
+	var deleted int64
+	if err := r.db.QueryRowContext(ctx, query, retentionHours).Scan(&deleted); err != nil {
+		return 0, fmt.Errorf("failed to prune title views: %w", err)
+	}
+	return deleted, nil
+}
+
+// GetActivityTrendingTitles mengambil titles dengan trending score tertinggi untuk window tertentu
+// Return slice kosong kalau belum ada aktivitas (atau TrendingScores belum pernah dihitung)
+func (r *TitleRepository) GetActivityTrendingTitles(window string, limit int) ([]*models.FilmCardData, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT TOP (@p2)
+			f.title_id,
+			f.name,
+			f.startYear,
+			f.vote_average,
+			f.vote_count,
+			f.genre_name,
+			cs.community_score
+		FROM TrendingScores ts
+		CROSS APPLY dbo.fnGetFilmCardDetail(ts.title_id) f
+		LEFT JOIN TitleCommunityScores cs ON cs.title_id = ts.title_id
+		WHERE ts.time_window = @p1
+		ORDER BY ts.score DESC, ts.title_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, window, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trending titles: %w", err)
+	}
+	defer rows.Close()
+
+	titles := make([]*models.FilmCardData, 0)
+	for rows.Next() {
+		var title models.FilmCardData
+		if err := rows.Scan(
+			&title.TitleID,
+			&title.Name,
+			&title.StartYear,
+			&title.VoteAverage,
+			&title.VoteCount,
+			&title.GenreName,
+			&title.CommunityScore,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan trending title: %w", err)
+		}
+		titles = append(titles, &title)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating trending titles: %w", err)
+	}
+
+	return titles, nil
+}
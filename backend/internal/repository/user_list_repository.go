@@ -0,0 +1,365 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"film-dashboard-api/internal/models"
+)
+
+var (
+	// ErrListNotFound dikembalikan kalau list_id / slug tidak ada
+	ErrListNotFound = errors.New("list not found")
+	// ErrListEntryNotFound dikembalikan kalau title tidak ada di list
+	ErrListEntryNotFound = errors.New("title is not in this list")
+	// ErrListEntryExists dikembalikan kalau title sudah ada di list
+	ErrListEntryExists = errors.New("title is already in this list")
+)
+
+// UserListRepository adalah struct yang berisi semua function untuk operasi database UserLists & UserListEntries
+type UserListRepository struct {
+	db *sql.DB
+}
+
+// NewUserListRepository adalah constructor untuk bikin instance UserListRepository
+func NewUserListRepository(db *sql.DB) *UserListRepository {
+	return &UserListRepository{
+		db: db,
+	}
+}
+
+// userListSelect dipakai oleh semua query yang return UserList (urutan kolom = urutan scanUserList)
+const userListSelect = `SELECT
+			l.list_id,
+			l.user_id,
+			u.username,
+			l.name,
+			l.description,
+			l.slug,
+			l.visibility,
+			(SELECT COUNT(*) FROM UserListEntries e WHERE e.list_id = l.list_id) AS entry_count,
+			l.created_at,
+			l.updated_at
+		FROM UserLists l
+		INNER JOIN Users u ON l.user_id = u.user_id`
+
+// scanUserList scan satu row dari userListSelect
+func scanUserList(row interface{ Scan(...interface{}) error }) (*models.UserList, error) {
+	list := &models.UserList{}
+	err := row.Scan(
+		&list.ListID,
+		&list.UserID,
+		&list.Username,
+		&list.Name,
+		&list.Description,
+		&list.Slug,
+		&list.Visibility,
+		&list.EntryCount,
+		&list.CreatedAt,
+		&list.UpdatedAt,
+	)
+	return list, err
+}
+
+// CreateList membuat list baru dan return list yang sudah tersimpan
+func (r *UserListRepository) CreateList(userID int, name string, description *string, slug string, visibility string) (*models.UserList, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var listID int
+	err := r.db.QueryRowContext(ctx, `INSERT INTO UserLists (user_id, name, description, slug, visibility)
+		OUTPUT INSERTED.list_id
+		VALUES (@p1, @p2, @p3, @p4, @p5)`, userID, name, description, slug, visibility).Scan(&listID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create list: %w", err)
+	}
+
+	return r.GetListByID(listID)
+}
+
+// UpdateList update name, description, dan visibility list
+func (r *UserListRepository) UpdateList(listID int, name string, description *string, visibility string) (*models.UserList, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, `UPDATE UserLists
+		SET name = @p2, description = @p3, visibility = @p4, updated_at = GETDATE()
+		WHERE list_id = @p1`, listID, name, description, visibility)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update list: %w", err)
+	}
+
+	return r.GetListByID(listID)
+}
+
+// DeleteList menghapus list (entries ikut terhapus lewat ON DELETE CASCADE)
+func (r *UserListRepository) DeleteList(listID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM UserLists WHERE list_id = @p1`, listID); err != nil {
+		return fmt.Errorf("failed to delete list: %w", err)
+	}
+	return nil
+}
+
+// GetListByID mengambil list by ID, return ErrListNotFound kalau tidak ada
+func (r *UserListRepository) GetListByID(listID int) (*models.UserList, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	list, err := scanUserList(r.db.QueryRowContext(ctx, userListSelect+`
+		WHERE l.list_id = @p1`, listID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrListNotFound
+		}
+		return nil, fmt.Errorf("failed to get list: %w", err)
+	}
+	return list, nil
+}
+
+// GetListBySlug mengambil list by slug, return ErrListNotFound kalau tidak ada
+func (r *UserListRepository) GetListBySlug(slug string) (*models.UserList, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	list, err := scanUserList(r.db.QueryRowContext(ctx, userListSelect+`
+		WHERE l.slug = @p1`, slug))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrListNotFound
+		}
+		return nil, fmt.Errorf("failed to get list: %w", err)
+	}
+	return list, nil
+}
+
+// GetListsByUser mengambil semua list milik user (semua visibility), terbaru di-update dulu
+func (r *UserListRepository) GetListsByUser(userID int) ([]*models.UserList, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, userListSelect+`
+		WHERE l.user_id = @p1
+		ORDER BY l.updated_at DESC, l.list_id DESC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lists by user: %w", err)
+	}
+	defer rows.Close()
+
+	lists := make([]*models.UserList, 0)
+	for rows.Next() {
+		list, err := scanUserList(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan list: %w", err)
+		}
+		lists = append(lists, list)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating lists: %w", err)
+	}
+
+	return lists, nil
+}
+
+// GetEntries mengambil entries list secara paged (urut position), join ke film card data
+func (r *UserListRepository) GetEntries(listID int, page int, limit int) ([]*models.UserListEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT
+			e.position,
+			e.note,
+			e.added_at,
+			f.title_id,
+			f.name,
+			f.startYear,
+			f.vote_average,
+			f.vote_count,
+			f.genre_name,
+			cs.community_score
+		FROM UserListEntries e
+		CROSS APPLY dbo.fnGetFilmCardDetail(e.title_id) f
+		LEFT JOIN TitleCommunityScores cs ON cs.title_id = f.title_id
+		WHERE e.list_id = @p1
+		ORDER BY e.position, e.added_at
+		OFFSET @p2 ROWS FETCH NEXT @p3 ROWS ONLY
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, listID, (page-1)*limit, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*models.UserListEntry, 0)
+	for rows.Next() {
+		entry := &models.UserListEntry{Title: &models.FilmCardData{}}
+		err := rows.Scan(
+			&entry.Position,
+			&entry.Note,
+			&entry.AddedAt,
+			&entry.Title.TitleID,
+			&entry.Title.Name,
+			&entry.Title.StartYear,
+			&entry.Title.VoteAverage,
+			&entry.Title.VoteCount,
+			&entry.Title.GenreName,
+			&entry.Title.CommunityScore,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan list entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating list entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// AddEntry menambahkan title ke akhir list (position = MAX + 1)
+// Return ErrTitleNotFound / ErrListEntryExists sesuai kondisi
+func (r *UserListRepository) AddEntry(listID int, titleID string, note *string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var titleExists, entryExists int
+	err := r.db.QueryRowContext(ctx, `SELECT
+		(SELECT COUNT(*) FROM titles WHERE title_id = @p2),
+		(SELECT COUNT(*) FROM UserListEntries WHERE list_id = @p1 AND title_id = @p2)`,
+		listID, titleID).Scan(&titleExists, &entryExists)
+	if err != nil {
+		return fmt.Errorf("failed to check list entry: %w", err)
+	}
+	if titleExists == 0 {
+		return ErrTitleNotFound
+	}
+	if entryExists > 0 {
+		return ErrListEntryExists
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO UserListEntries (list_id, title_id, position, note)
+		SELECT @p1, @p2, COALESCE(MAX(position), 0) + 1, @p3
+		FROM UserListEntries
+		WHERE list_id = @p1;
+
+		UPDATE UserLists SET updated_at = GETDATE() WHERE list_id = @p1;
+	`, listID, titleID, note)
+	if err != nil {
+		return fmt.Errorf("failed to add list entry: %w", err)
+	}
+	return nil
+}
+
+// UpdateEntryNote update note satu entry, return ErrListEntryNotFound kalau title tidak ada di list
+func (r *UserListRepository) UpdateEntryNote(listID int, titleID string, note *string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `UPDATE UserListEntries SET note = @p3
+		WHERE list_id = @p1 AND title_id = @p2`, listID, titleID, note)
+	if err != nil {
+		return fmt.Errorf("failed to update list entry: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrListEntryNotFound
+	}
+
+	_, err = r.db.ExecContext(ctx, `UPDATE UserLists SET updated_at = GETDATE() WHERE list_id = @p1`, listID)
+	if err != nil {
+		return fmt.Errorf("failed to touch list: %w", err)
+	}
+	return nil
+}
+
+// RemoveEntry menghapus title dari list dan merapatkan position entries setelahnya
+func (r *UserListRepository) RemoveEntry(listID int, titleID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var position int
+	err = tx.QueryRowContext(ctx, `DELETE FROM UserListEntries
+		OUTPUT DELETED.position
+		WHERE list_id = @p1 AND title_id = @p2`, listID, titleID).Scan(&position)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrListEntryNotFound
+		}
+		return fmt.Errorf("failed to remove list entry: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE UserListEntries SET position = position - 1
+		WHERE list_id = @p1 AND position > @p2;
+
+		UPDATE UserLists SET updated_at = GETDATE() WHERE list_id = @p1;
+	`, listID, position)
+	if err != nil {
+		return fmt.Errorf("failed to reorder list entries: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetEntryTitleIDs mengambil semua title_id di list (urut position), dipakai untuk validasi reorder
+func (r *UserListRepository) GetEntryTitleIDs(listID int) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `SELECT title_id FROM UserListEntries
+		WHERE list_id = @p1 ORDER BY position`, listID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list entries: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan list entry: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// ReorderEntries set position setiap entry sesuai urutan titleIDs (1..n) dalam satu transaction
+func (r *UserListRepository) ReorderEntries(listID int, titleIDs []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i, titleID := range titleIDs {
+		_, err := tx.ExecContext(ctx, `UPDATE UserListEntries SET position = @p3
+			WHERE list_id = @p1 AND title_id = @p2`, listID, titleID, i+1)
+		if err != nil {
+			return fmt.Errorf("failed to reorder list entries: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE UserLists SET updated_at = GETDATE() WHERE list_id = @p1`, listID); err != nil {
+		return fmt.Errorf("failed to touch list: %w", err)
+	}
+
+	return tx.Commit()
+}
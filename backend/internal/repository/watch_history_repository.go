@@ -0,0 +1,363 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"film-dashboard-api/internal/models"
+)
+
+var (
+	// ErrWatchNotFound dikembalikan kalau watch_id tidak ada (atau bukan milik user)
+	ErrWatchNotFound = errors.New("watch entry not found")
+	// ErrReviewNotOwned dikembalikan kalau review_id tidak ada atau bukan milik user
+	ErrReviewNotOwned = errors.New("review not found for this user")
+	// ErrReviewTitleMismatch dikembalikan kalau review_id milik user tapi untuk title lain
+	ErrReviewTitleMismatch = errors.New("review is not for this title")
+)
+
+// WatchHistoryRepository adalah struct yang berisi semua function untuk operasi database WatchHistory
+type WatchHistoryRepository struct {
+	db *sql.DB
+}
+
+// NewWatchHistoryRepository adalah constructor untuk bikin instance WatchHistoryRepository
+func NewWatchHistoryRepository(db *sql.DB) *WatchHistoryRepository {
+	return &WatchHistoryRepository{
+		db: db,
+	}
+}
+
+// watchHistorySelect dipakai oleh semua query yang return WatchHistoryEntry
+// (urutan kolom = urutan scanWatchHistoryEntry)
+const watchHistorySelect = `SELECT
+			h.watch_id,
+			h.title_id,
+			t.name,
+			ty.type_name,
+			t.startYear,
+			t.runtimeMinutes,
+			CONVERT(NVARCHAR(10), h.watched_on, 23) AS watched_on,
+			h.is_rewatch,
+			h.review_id,
+			h.note,
+			e.parent_title_id,
+			p.name AS parent_name,
+			e.seasonNumber,
+			e.episodeNumber
+		FROM WatchHistory h
+		JOIN titles t ON t.title_id = h.title_id
+		LEFT JOIN types ty ON ty.type_id = t.type_id
+		LEFT JOIN episodes e ON e.title_id = h.title_id
+		LEFT JOIN titles p ON p.title_id = e.parent_title_id`
+
+// scanWatchHistoryEntry scan satu row dari watchHistorySelect
+func scanWatchHistoryEntry(row interface{ Scan(...interface{}) error }) (*models.WatchHistoryEntry, error) {
+	entry := &models.WatchHistoryEntry{}
+	var parentID *string
+	episode := &models.EpisodeParent{}
+	err := row.Scan(
+		&entry.WatchID,
+		&entry.TitleID,
+		&entry.Name,
+		&entry.Type,
+		&entry.StartYear,
+		&entry.RuntimeMinutes,
+		&entry.WatchedOn,
+		&entry.IsRewatch,
+		&entry.ReviewID,
+		&entry.Note,
+		&parentID,
+		&episode.ParentName,
+		&episode.SeasonNumber,
+		&episode.EpisodeNumber,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if parentID != nil {
+		episode.ParentTitleID = *parentID
+		entry.Episode = episode
+	}
+	return entry, nil
+}
+
+// LogWatch menyimpan satu tontonan dan return entry yang tersimpan
+// isRewatch nil = otomatis (true kalau user sudah pernah log title ini)
+// Return ErrTitleNotFound / ErrReviewNotOwned / ErrReviewTitleMismatch sesuai kondisi
+func (r *WatchHistoryRepository) LogWatch(userID int, titleID string, watchedOn time.Time, isRewatch *bool, reviewID *int, note *string) (*models.WatchHistoryEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// 1. Validate title, review ownership, dan review harus untuk title yang sama
+	var titleExists, reviewOwned, reviewMatches int
+	err := r.db.QueryRowContext(ctx, `SELECT
+		(SELECT COUNT(*) FROM titles WHERE title_id = @p1),
+		(SELECT COUNT(*) FROM Reviews WHERE review_id = @p2 AND user_id = @p3),
+		(SELECT COUNT(*) FROM Reviews WHERE review_id = @p2 AND user_id = @p3 AND title_id = @p1)`,
+		titleID, reviewID, userID).Scan(&titleExists, &reviewOwned, &reviewMatches)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate watch entry: %w", err)
+	}
+	if titleExists == 0 {
+		return nil, ErrTitleNotFound
+	}
+	if reviewID != nil && reviewOwned == 0 {
+		return nil, ErrReviewNotOwned
+	}
+	if reviewID != nil && reviewMatches == 0 {
+		return nil, ErrReviewTitleMismatch
+	}
+
+	// 2. Insert (rewatch otomatis kalau sudah ada entry sebelumnya di tanggal <= watchedOn)
+	var watchID int
+	err = r.db.QueryRowContext(ctx, `INSERT INTO WatchHistory (user_id, title_id, watched_on, is_rewatch, review_id, note)
+		OUTPUT INSERTED.watch_id
+		VALUES (@p1, @p2, @p3,
+			COALESCE(@p4, CASE WHEN EXISTS (
+				SELECT 1 FROM WatchHistory WHERE user_id = @p1 AND title_id = @p2 AND watched_on <= @p3
+			) THEN 1 ELSE 0 END),
+			@p5, @p6)`,
+		userID, titleID, watchedOn, isRewatch, reviewID, note).Scan(&watchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to log watch: %w", err)
+	}
+
+	entry, err := scanWatchHistoryEntry(r.db.QueryRowContext(ctx, watchHistorySelect+`
+		WHERE h.watch_id = @p1`, watchID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watch entry: %w", err)
+	}
+	return entry, nil
+}
+
+// DeleteWatch menghapus satu entry milik user, return ErrWatchNotFound kalau tidak ada
+func (r *WatchHistoryRepository) DeleteWatch(userID int, watchID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM WatchHistory WHERE watch_id = @p1 AND user_id = @p2`, watchID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete watch entry: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrWatchNotFound
+	}
+	return nil
+}
+
+// GetHistory mengambil diary user secara paged (terbaru dulu)
+// from dan to optional (nil = tanpa batas), inclusive
+// Return: entries untuk page yang diminta, total entries di range, dan error
+func (r *WatchHistoryRepository) GetHistory(userID int, from *time.Time, to *time.Time, page int, limit int) ([]*models.WatchHistoryEntry, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	where := `
+		WHERE h.user_id = @p1
+		  AND (@p2 IS NULL OR h.watched_on >= @p2)
+		  AND (@p3 IS NULL OR h.watched_on <= @p3)`
+
+	// 1. Total entries (untuk pagination)
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM WatchHistory h`+where, userID, from, to).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count watch history: %w", err)
+	}
+
+	// 2. Entries untuk page ini
+	query := watchHistorySelect + where + `
+		ORDER BY h.watched_on DESC, h.watch_id DESC
+		OFFSET @p4 ROWS FETCH NEXT @p5 ROWS ONLY`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, from, to, (page-1)*limit, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get watch history: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*models.WatchHistoryEntry, 0)
+	for rows.Next() {
+		entry, err := scanWatchHistoryEntry(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan watch entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating watch history: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+// GetSeriesProgress menghitung episode progress user untuk sebuah series
+// Return nil (tanpa error) kalau series tidak punya episodes
+func (r *WatchHistoryRepository) GetSeriesProgress(userID int, seriesID string) (*models.SeriesProgress, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// 1. Progress per season
+	rows, err := r.db.QueryContext(ctx, `SELECT
+		e.seasonNumber,
+		COUNT(*) AS total_episodes,
+		SUM(CASE WHEN EXISTS (
+			SELECT 1 FROM WatchHistory h WHERE h.user_id = @p2 AND h.title_id = e.title_id
+		) THEN 1 ELSE 0 END) AS watched_episodes
+	FROM episodes e
+	WHERE e.parent_title_id = @p1
+	GROUP BY e.seasonNumber
+	ORDER BY e.seasonNumber`, seriesID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series progress: %w", err)
+	}
+	defer rows.Close()
+
+	progress := &models.SeriesProgress{
+		SeriesID: seriesID,
+		Seasons:  make([]*models.SeasonProgress, 0),
+	}
+	for rows.Next() {
+		season := &models.SeasonProgress{}
+		if err := rows.Scan(&season.SeasonNumber, &season.TotalEpisodes, &season.WatchedEpisodes); err != nil {
+			return nil, fmt.Errorf("failed to scan season progress: %w", err)
+		}
+		progress.TotalEpisodes += season.TotalEpisodes
+		progress.WatchedEpisodes += season.WatchedEpisodes
+		progress.Seasons = append(progress.Seasons, season)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating season progress: %w", err)
+	}
+	rows.Close()
+
+	if progress.TotalEpisodes == 0 {
+		return nil, nil
+	}
+	progress.Percent = float64(progress.WatchedEpisodes) * 100 / float64(progress.TotalEpisodes)
+
+	// 2. Next episode = episode pertama yang belum ditonton
+	next := &models.Episode{}
+	err = r.db.QueryRowContext(ctx, `SELECT TOP 1
+		e.title_id,
+		t.name,
+		e.seasonNumber,
+		e.episodeNumber,
+		t.vote_average,
+		t.vote_count,
+		t.runtimeMinutes
+	FROM episodes e
+	LEFT JOIN titles t ON t.title_id = e.title_id
+	WHERE e.parent_title_id = @p1
+	  AND NOT EXISTS (SELECT 1 FROM WatchHistory h WHERE h.user_id = @p2 AND h.title_id = e.title_id)
+	ORDER BY e.seasonNumber, e.episodeNumber, e.title_id`, seriesID, userID).Scan(
+		&next.TitleID,
+		&next.Name,
+		&next.SeasonNumber,
+		&next.EpisodeNumber,
+		&next.VoteAverage,
+		&next.VoteCount,
+		&next.RuntimeMinutes,
+	)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get next episode: %w", err)
+	}
+	if err == nil {
+		progress.NextEpisode = next
+	}
+
+	return progress, nil
+}
+
+// GetYearInReview menghitung statistik tontonan user untuk satu tahun kalender
+func (r *WatchHistoryRepository) GetYearInReview(userID int, year int) (*models.YearInReview, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	stats := &models.YearInReview{
+		Year:      year,
+		ByMonth:   make([]*models.MonthStats, 0),
+		TopGenres: make([]*models.GenreStats, 0),
+	}
+
+	// 1. Totals
+	err := r.db.QueryRowContext(ctx, `SELECT
+		COUNT(*),
+		COUNT(DISTINCT h.title_id),
+		COALESCE(SUM(CASE WHEN h.is_rewatch = 1 THEN 1 ELSE 0 END), 0),
+		COALESCE(SUM(CASE WHEN e.title_id IS NOT NULL THEN 1 ELSE 0 END), 0),
+		COALESCE(SUM(CAST(t.runtimeMinutes AS BIGINT)), 0),
+		COUNT(h.review_id)
+	FROM WatchHistory h
+	JOIN titles t ON t.title_id = h.title_id
+	LEFT JOIN episodes e ON e.title_id = h.title_id
+	WHERE h.user_id = @p1 AND YEAR(h.watched_on) = @p2`, userID, year).Scan(
+		&stats.Entries,
+		&stats.UniqueTitles,
+		&stats.Rewatches,
+		&stats.EpisodesWatched,
+		&stats.MinutesWatched,
+		&stats.ReviewsLinked,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get year totals: %w", err)
+	}
+	stats.HoursWatched = float64(stats.MinutesWatched) / 60
+
+	// 2. Per bulan
+	rows, err := r.db.QueryContext(ctx, `SELECT
+		MONTH(h.watched_on) AS month,
+		COUNT(*),
+		COALESCE(SUM(CAST(t.runtimeMinutes AS BIGINT)), 0)
+	FROM WatchHistory h
+	JOIN titles t ON t.title_id = h.title_id
+	WHERE h.user_id = @p1 AND YEAR(h.watched_on) = @p2
+	GROUP BY MONTH(h.watched_on)
+	ORDER BY month`, userID, year)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get monthly stats: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		month := &models.MonthStats{}
+		var minutes int
+		if err := rows.Scan(&month.Month, &month.Entries, &minutes); err != nil {
+			return nil, fmt.Errorf("failed to scan monthly stats: %w", err)
+		}
+		month.Hours = float64(minutes) / 60
+		stats.ByMonth = append(stats.ByMonth, month)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating monthly stats: %w", err)
+	}
+	rows.Close()
+
+	// 3. Top genres (episode ikut genre series-nya)
+	rows, err = r.db.QueryContext(ctx, `SELECT TOP 5 gt.genre_name, COUNT(*) AS entries
+	FROM WatchHistory h
+	LEFT JOIN episodes e ON e.title_id = h.title_id
+	JOIN genres g ON g.title_id = COALESCE(e.parent_title_id, h.title_id)
+	JOIN genre_types gt ON gt.genre_type_id = g.genre_type_id
+	WHERE h.user_id = @p1 AND YEAR(h.watched_on) = @p2
+	GROUP BY gt.genre_name
+	ORDER BY entries DESC, gt.genre_name`, userID, year)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get genre stats: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		genre := &models.GenreStats{}
+		if err := rows.Scan(&genre.GenreName, &genre.Entries); err != nil {
+			return nil, fmt.Errorf("failed to scan genre stats: %w", err)
+		}
+		stats.TopGenres = append(stats.TopGenres, genre)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating genre stats: %w", err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"film-dashboard-api/internal/models"
+)
+
+// WatchlistRepository adalah struct yang berisi semua function untuk operasi database Watchlist
+type WatchlistRepository struct {
+	db *sql.DB
+}
+
+// NewWatchlistRepository adalah constructor untuk bikin instance WatchlistRepository
+func NewWatchlistRepository(db *sql.DB) *WatchlistRepository {
+	return &WatchlistRepository{
+		db: db,
+	}
+}
+
+// AddToWatchlist menambahkan title ke watchlist user
+// Idempotent: kalau title sudah ada di watchlist, return entry yang sudah ada
+// Return ErrTitleNotFound kalau title_id tidak ada
+func (r *WatchlistRepository) AddToWatchlist(userID int, titleID string) (*models.Watchlist, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var exists int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM titles WHERE title_id = @p1`, titleID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check title: %w", err)
+	}
+	if exists == 0 {
+		return nil, ErrTitleNotFound
+	}
+
+	// MERGE + HOLDLOCK supaya cek-lalu-insert atomic: dua request bersamaan
+	// tidak bisa sama-sama lolos NOT EXISTS lalu kena unique constraint
+	query := `
+		MERGE Watchlist WITH (HOLDLOCK) AS target
+		USING (SELECT @p1 AS user_id, @p2 AS title_id) AS source
+			ON target.user_id = source.user_id AND target.title_id = source.title_id
+		WHEN NOT MATCHED THEN
+			INSERT (user_id, title_id) VALUES (source.user_id, source.title_id);
+
+		SELECT watchlist_id, user_id, title_id, added_at
+		FROM Watchlist
+		WHERE user_id = @p1 AND title_id = @p2;
+	`
+
+	var entry models.Watchlist
+	err = r.db.QueryRowContext(ctx, query, userID, titleID).Scan(
+		&entry.WatchlistID,
+		&entry.UserID,
+		&entry.TitleID,
+		&entry.AddedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add to watchlist: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// RemoveFromWatchlist menghapus title dari watchlist user
+// Return false (tanpa error) kalau title memang tidak ada di watchlist
+func (r *WatchlistRepository) RemoveFromWatchlist(userID int, titleID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM Watchlist WHERE user_id = @p1 AND title_id = @p2`, userID, titleID)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove from watchlist: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to remove from watchlist: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// GetWatchlistEntry mengambil entry watchlist user untuk title tertentu
+// Return nil (tanpa error) kalau title tidak ada di watchlist
+func (r *WatchlistRepository) GetWatchlistEntry(userID int, titleID string) (*models.Watchlist, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var entry models.Watchlist
+	err := r.db.QueryRowContext(ctx, `SELECT watchlist_id, user_id, title_id, added_at
+		FROM Watchlist
+		WHERE user_id = @p1 AND title_id = @p2`, userID, titleID).Scan(
+		&entry.WatchlistID,
+		&entry.UserID,
+		&entry.TitleID,
+		&entry.AddedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get watchlist entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// GetWatchlist mengambil watchlist user secara paged (terbaru dulu), join ke film card data
+// Return: items untuk page yang diminta, total entries, dan error
+func (r *WatchlistRepository) GetWatchlist(userID int, page int, limit int) ([]*models.WatchlistItem, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// 1. Total entries (untuk pagination), pakai join yang sama dengan query page
+	// supaya title yang tidak punya film card tidak ikut dihitung
+	var total int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM Watchlist w
+		CROSS APPLY dbo.fnGetFilmCardDetail(w.title_id) f
+		WHERE w.user_id = @p1`, userID).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count watchlist: %w", err)
+	}
+
+	// 2. Entries untuk page ini
+	query := `
+		SELECT
+			w.watchlist_id,
+			w.added_at,
+			f.title_id,
+			f.name,
+			f.startYear,
+			f.vote_average,
+			f.vote_count,
+			f.genre_name,
+			cs.community_score
+		FROM Watchlist w
+		CROSS APPLY dbo.fnGetFilmCardDetail(w.title_id) f
+		LEFT JOIN TitleCommunityScores cs ON cs.title_id = f.title_id
+		WHERE w.user_id = @p1
+		ORDER BY w.added_at DESC, w.watchlist_id DESC
+		OFFSET @p2 ROWS FETCH NEXT @p3 ROWS ONLY
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, (page-1)*limit, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get watchlist: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]*models.WatchlistItem, 0)
+	for rows.Next() {
+		item := &models.WatchlistItem{Title: &models.FilmCardData{}}
+		err := rows.Scan(
+			&item.WatchlistID,
+			&item.AddedAt,
+			&item.Title.TitleID,
+			&item.Title.Name,
+			&item.Title.StartYear,
+			&item.Title.VoteAverage,
+			&item.Title.VoteCount,
+			&item.Title.GenreName,
+			&item.Title.CommunityScore,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan watchlist item: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating watchlist: %w", err)
+	}
+
+	return items, total, nil
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"film-dashboard-api/internal/models"
+	"film-dashboard-api/internal/repository"
+)
+
+// Batas untuk ?days= di title analytics
+const (
+	DefaultAnalyticsDays = 30
+	MaxAnalyticsDays     = 365
+)
+
+// AnalyticsService berisi business logic untuk per-title analytics (executive dashboard)
+type AnalyticsService struct {
+	analyticsRepo *repository.AnalyticsRepository
+}
+
+// NewAnalyticsService adalah constructor untuk bikin instance AnalyticsService
+func NewAnalyticsService(analyticsRepo *repository.AnalyticsRepository) *AnalyticsService {
+	return &AnalyticsService{
+		analyticsRepo: analyticsRepo,
+	}
+}
+
+// GetTitleAnalytics mengambil daily views, unique viewers dan review conversion satu title
+// untuk `days` hari terakhir (1 - MaxAnalyticsDays, termasuk hari ini)
+func (s *AnalyticsService) GetTitleAnalytics(titleID string, days int) (*models.TitleAnalytics, error) {
+	if days < 1 || days > MaxAnalyticsDays {
+		return nil, newValidationError("days must be between 1 and 365")
+	}
+	return s.analyticsRepo.GetTitleAnalytics(titleID, days)
+}
@@ -0,0 +1,293 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"film-dashboard-api/internal/models"
+	"film-dashboard-api/internal/repository"
+)
+
+// ErrAutocompleteNotReady dikembalikan kalau index belum pernah berhasil di-load dari database
+var ErrAutocompleteNotReady = errors.New("autocomplete index is not ready yet")
+
+// AutocompleteMaxLimit adalah jumlah suggestion maksimal per kind untuk satu request
+const AutocompleteMaxLimit = 20
+
+// autocompleteBucketRunes adalah panjang prefix (dalam huruf) yang hasilnya di-precompute per bucket
+// Prefix sependek ini match terlalu banyak nama untuk di-scan per request
+const autocompleteBucketRunes = 3
+
+// AutocompleteService menyimpan prefix index in-memory untuk titles dan persons
+// Index di-build ulang secara periodik dari database (lihat Start), request typeahead
+// tidak pernah query ke SQL Server
+type AutocompleteService struct {
+	autocompleteRepo *repository.AutocompleteRepository
+
+	mu        sync.RWMutex
+	titles    *prefixIndex
+	persons   *prefixIndex
+	indexedAt time.Time
+}
+
+// NewAutocompleteService adalah constructor untuk bikin instance AutocompleteService
+// Index masih kosong sampai Refresh / Start dipanggil
+func NewAutocompleteService(autocompleteRepo *repository.AutocompleteRepository) *AutocompleteService {
+	return &AutocompleteService{
+		autocompleteRepo: autocompleteRepo,
+	}
+}
+
+// Start load index pertama kali lalu refresh setiap interval (di background goroutine)
+// Kalau refresh gagal, index lama tetap dipakai
+func (s *AutocompleteService) Start(interval time.Duration) {
+	go func() {
+		s.refreshAndLog()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			s.refreshAndLog()
+		}
+	}()
+}
+
+func (s *AutocompleteService) refreshAndLog() {
+	start := time.Now()
+	if err := s.Refresh(); err != nil {
+		fmt.Printf("⚠️  Autocomplete index refresh failed: %v\n", err)
+		return
+	}
+	fmt.Printf("✅ Autocomplete index refreshed in %v\n", time.Since(start).Round(time.Millisecond))
+}
+
+// Refresh load ulang semua titles & persons dari database lalu swap index
+// Index baru di-build di luar lock, jadi request yang sedang jalan tidak ke-block
+func (s *AutocompleteService) Refresh() error {
+	titles, err := s.autocompleteRepo.GetTitleSuggestions()
+	if err != nil {
+		return err
+	}
+	persons, err := s.autocompleteRepo.GetPersonSuggestions()
+	if err != nil {
+		return err
+	}
+
+	titleIndex := newPrefixIndex(titles)
+	personIndex := newPrefixIndex(persons)
+
+	s.mu.Lock()
+	s.titles = titleIndex
+	s.persons = personIndex
+	s.indexedAt = time.Now()
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Suggest return maksimal limit title dan limit person yang match prefix query
+// Match dihitung per kata, jadi "bad" menemukan "Breaking Bad"
+// Urutan: yang match dari awal nama dulu, lalu weight terbesar
+func (s *AutocompleteService) Suggest(query string, limit int) (*models.AutocompleteResponse, error) {
+	s.mu.RLock()
+	titles, persons, indexedAt := s.titles, s.persons, s.indexedAt
+	s.mu.RUnlock()
+
+	if titles == nil || persons == nil {
+		return nil, ErrAutocompleteNotReady
+	}
+
+	prefix := normalizeName(query)
+	return &models.AutocompleteResponse{
+		Query:     query,
+		Titles:    titles.search(prefix, limit),
+		Persons:   persons.search(prefix, limit),
+		IndexedAt: indexedAt,
+	}, nil
+}
+
+// indexedName adalah satu nama (Name atau OriginalName) yang sudah dinormalisasi
+type indexedName struct {
+	norm string
+	item *models.AutocompleteSuggestion
+}
+
+// wordKey adalah awal satu kata di sebuah nama (hanya awal kata, bukan semua suffix,
+// supaya jumlah key linear terhadap jumlah kata)
+type wordKey struct {
+	word string
+	name *indexedName
+}
+
+// prefixBucket berisi top AutocompleteMaxLimit items untuk satu prefix pendek, urut weight
+// leading = nama dimulai dengan prefix, other = prefix hanya match di kata selanjutnya
+type prefixBucket struct {
+	leading []*models.AutocompleteSuggestion
+	other   []*models.AutocompleteSuggestion
+}
+
+// prefixIndex menyimpan:
+//   - words: semua awal kata, sorted alfabetis, lookup pakai binary search (prefix panjang)
+//   - buckets: hasil top-N yang sudah di-ranking untuk prefix 1..autocompleteBucketRunes huruf
+type prefixIndex struct {
+	words   []wordKey
+	buckets map[string]*prefixBucket
+}
+
+// newPrefixIndex membuat key untuk setiap kata di Name (dan OriginalName kalau ada)
+// lalu precompute bucket untuk semua prefix pendek
+func newPrefixIndex(items []*models.AutocompleteSuggestion) *prefixIndex {
+	idx := &prefixIndex{words: make([]wordKey, 0, len(items)*2)}
+
+	// bucketItems: prefix pendek -> item -> leading (item match dari awal nama)
+	bucketItems := make(map[string]map[*models.AutocompleteSuggestion]bool)
+	for _, item := range items {
+		names := []string{item.Name}
+		if item.OriginalName != nil {
+			names = append(names, *item.OriginalName)
+		}
+		for _, name := range names {
+			entry := &indexedName{norm: normalizeName(name), item: item}
+			for i, word := range strings.Fields(entry.norm) {
+				idx.words = append(idx.words, wordKey{word: word, name: entry})
+
+				runes := []rune(word)
+				for n := 1; n <= autocompleteBucketRunes && n <= len(runes); n++ {
+					prefix := string(runes[:n])
+					matches, ok := bucketItems[prefix]
+					if !ok {
+						matches = make(map[*models.AutocompleteSuggestion]bool)
+						bucketItems[prefix] = matches
+					}
+					matches[item] = matches[item] || i == 0
+				}
+			}
+		}
+	}
+
+	sort.Slice(idx.words, func(i, j int) bool {
+		return idx.words[i].word < idx.words[j].word
+	})
+
+	idx.buckets = make(map[string]*prefixBucket, len(bucketItems))
+	for prefix, matches := range bucketItems {
+		bucket := &prefixBucket{}
+		for item, leading := range matches {
+			if leading {
+				bucket.leading = append(bucket.leading, item)
+			} else {
+				bucket.other = append(bucket.other, item)
+			}
+		}
+		bucket.leading = topByWeight(bucket.leading, AutocompleteMaxLimit)
+		bucket.other = topByWeight(bucket.other, AutocompleteMaxLimit)
+		idx.buckets[prefix] = bucket
+	}
+	return idx
+}
+
+// search return top limit items yang punya kata dengan prefix tertentu
+// Urutan: leading match dulu (nama dimulai dengan prefix), lalu weight, lalu nama
+func (idx *prefixIndex) search(prefix string, limit int) []*models.AutocompleteSuggestion {
+	results := make([]*models.AutocompleteSuggestion, 0, limit)
+	if prefix == "" {
+		return results
+	}
+
+	// 1. Prefix pendek satu kata: pakai hasil precompute
+	if !strings.Contains(prefix, " ") && utf8.RuneCountInString(prefix) <= autocompleteBucketRunes {
+		bucket, ok := idx.buckets[prefix]
+		if !ok {
+			return results
+		}
+		results = append(results, bucket.leading...)
+		results = append(results, bucket.other...)
+		if len(results) > limit {
+			results = results[:limit]
+		}
+		return results
+	}
+
+	// 2. Ambil kandidat dari kata query yang paling panjang (paling selektif), lalu cek
+	// seluruh query match di awal kata sebuah nama ("breaking b" -> "Breaking Bad")
+	words := strings.Fields(prefix)
+	anchor := words[0]
+	for _, word := range words[1:] {
+		if len(word) > len(anchor) {
+			anchor = word
+		}
+	}
+
+	leading := make(map[*models.AutocompleteSuggestion]bool)
+	start := sort.Search(len(idx.words), func(i int) bool {
+		return idx.words[i].word >= anchor
+	})
+	for i := start; i < len(idx.words) && strings.HasPrefix(idx.words[i].word, anchor); i++ {
+		name := idx.words[i].name
+		isLeading := strings.HasPrefix(name.norm, prefix)
+		if !isLeading && !strings.Contains(name.norm, " "+prefix) {
+			continue
+		}
+		leading[name.item] = leading[name.item] || isLeading
+	}
+
+	// 3. Ranking: leading match dulu, lalu weight, lalu nama
+	for item := range leading {
+		results = append(results, item)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if leading[a] != leading[b] {
+			return leading[a]
+		}
+		return heavierSuggestion(a, b)
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// topByWeight sort items berdasarkan weight (lalu nama) dan return maksimal n items pertama
+func topByWeight(items []*models.AutocompleteSuggestion, n int) []*models.AutocompleteSuggestion {
+	sort.Slice(items, func(i, j int) bool {
+		return heavierSuggestion(items[i], items[j])
+	})
+	if len(items) > n {
+		items = items[:n]
+	}
+	return items
+}
+
+// heavierSuggestion return true kalau a harus tampil sebelum b (weight terbesar, lalu nama)
+func heavierSuggestion(a, b *models.AutocompleteSuggestion) bool {
+	if a.Weight != b.Weight {
+		return a.Weight > b.Weight
+	}
+	return a.Name < b.Name
+}
+
+// normalizeName lowercase dan ganti semua karakter selain huruf/angka dengan spasi
+// contoh: "Spider-Man: No Way Home" -> "spider man no way home"
+func normalizeName(name string) string {
+	var b strings.Builder
+	b.Grow(len(name))
+	space := true
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+			space = false
+		} else if !space {
+			b.WriteByte(' ')
+			space = true
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"film-dashboard-api/internal/models"
+	"film-dashboard-api/internal/repository"
+)
+
+// defaultCommunityPriorMean dipakai kalau prior mean tidak di-set dan catalog belum punya vote
+const defaultCommunityPriorMean = 5.5
+
+// CommunityScoreService menjaga TitleCommunityScores tetap sinkron dengan Reviews
+// Semua title di-rebuild saat Start, setelah itu di-update per title lewat Refresh
+// setiap ada perubahan review (create, update, delete, hide, restore)
+type CommunityScoreService struct {
+	titleRepo *repository.TitleRepository
+	prior     models.CommunityScorePrior
+	autoMean  bool
+}
+
+// NewCommunityScoreService adalah constructor untuk bikin instance CommunityScoreService
+// priorMean nil = dihitung dari rata-rata vote catalog saat Start
+func NewCommunityScoreService(titleRepo *repository.TitleRepository, priorMean *float64, priorWeight int) *CommunityScoreService {
+	s := &CommunityScoreService{
+		titleRepo: titleRepo,
+		prior:     models.CommunityScorePrior{Mean: defaultCommunityPriorMean, Weight: priorWeight},
+		autoMean:  priorMean == nil,
+	}
+	if priorMean != nil {
+		s.prior.Mean = *priorMean
+	}
+	return s
+}
+
+// Start menentukan prior mean (kalau auto) lalu rebuild semua community scores di background goroutine
+// Prior di-resolve sebelum goroutine jalan supaya Refresh selalu pakai prior yang sama
+func (s *CommunityScoreService) Start() {
+	if s.autoMean {
+		mean, err := s.titleRepo.GetCatalogMeanRating()
+		if err != nil {
+			fmt.Printf("⚠️  Failed to compute catalog mean rating, using %.1f: %v\n", s.prior.Mean, err)
+		} else if mean != nil {
+			s.prior.Mean = *mean
+		}
+	}
+	fmt.Printf("✅ Community score prior: mean %.2f, weight %d\n", s.prior.Mean, s.prior.Weight)
+
+	go func() {
+		start := time.Now()
+		count, err := s.titleRepo.RefreshAllCommunityScores(s.prior)
+		if err != nil {
+			fmt.Printf("⚠️  Community score rebuild failed: %v\n", err)
+			return
+		}
+		fmt.Printf("✅ Community scores rebuilt for %d titles in %v\n", count, time.Since(start).Round(time.Millisecond))
+	}()
+}
+
+// Refresh menghitung ulang community score satu title
+// Error hanya di-log: review tetap tersimpan, score akan benar lagi di refresh / rebuild berikutnya
+func (s *CommunityScoreService) Refresh(titleID string) {
+	if titleID == "" {
+		return
+	}
+	if err := s.titleRepo.RefreshCommunityScore(titleID, s.prior); err != nil {
+		fmt.Printf("⚠️  Community score refresh failed for %s: %v\n", titleID, err)
+	}
+}
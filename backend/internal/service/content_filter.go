@@ -0,0 +1,142 @@
+package service
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	// scriptStyleBlockPattern cocok dengan block <script>/<style> beserta isinya
+	scriptStyleBlockPattern = regexp.MustCompile(`(?is)<(script|style)\b[^>]*>.*?</(script|style)\s*>`)
+	// htmlCommentPattern cocok dengan HTML comment
+	htmlCommentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+	// htmlTagPattern cocok dengan tag yang well-formed: "<" + nama tag + attributes (opsional) + ">"
+	// Nama tag dicek lagi terhadap htmlTagNames supaya text biasa seperti "x<y but y>z" tidak terhapus
+	htmlTagPattern = regexp.MustCompile(`</?([a-zA-Z][a-zA-Z0-9]*)(\s+[a-zA-Z_:][-a-zA-Z0-9_:.]*(\s*=\s*("[^"]*"|'[^']*'|[^\s"'=<>` + "`" + `]+))?)*\s*/?>`)
+)
+
+// htmlTagNames adalah nama HTML element yang di-strip oleh stripHTML
+var htmlTagNames = map[string]bool{
+	"a": true, "abbr": true, "address": true, "area": true, "article": true, "aside": true, "audio": true,
+	"b": true, "base": true, "bdi": true, "bdo": true, "blockquote": true, "body": true, "br": true, "button": true,
+	"canvas": true, "caption": true, "cite": true, "code": true, "col": true, "colgroup": true,
+	"data": true, "datalist": true, "dd": true, "del": true, "details": true, "dfn": true, "dialog": true,
+	"div": true, "dl": true, "dt": true, "em": true, "embed": true, "fieldset": true, "figcaption": true,
+	"figure": true, "font": true, "footer": true, "form": true, "frame": true, "frameset": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "head": true, "header": true,
+	"hr": true, "html": true, "i": true, "iframe": true, "img": true, "input": true, "ins": true, "kbd": true,
+	"label": true, "legend": true, "li": true, "link": true, "main": true, "map": true, "mark": true,
+	"marquee": true, "meta": true, "meter": true, "nav": true, "noscript": true, "object": true, "ol": true,
+	"optgroup": true, "option": true, "output": true, "p": true, "param": true, "picture": true, "pre": true,
+	"progress": true, "q": true, "rp": true, "rt": true, "ruby": true, "s": true, "samp": true, "script": true,
+	"section": true, "select": true, "small": true, "source": true, "span": true, "strike": true,
+	"strong": true, "style": true, "sub": true, "summary": true, "sup": true, "svg": true, "table": true,
+	"tbody": true, "td": true, "template": true, "textarea": true, "tfoot": true, "th": true, "thead": true,
+	"time": true, "title": true, "tr": true, "track": true, "tt": true, "u": true, "ul": true, "var": true,
+	"video": true, "wbr": true,
+}
+
+// ContentFilter adalah filter untuk user-generated text (review_text & comment body):
+// strip HTML/script, validate panjang, dan reject / mask blocked words
+type ContentFilter struct {
+	maxReviewLength int
+	blockedPattern  *regexp.Regexp // nil kalau tidak ada blocked words
+	maskBlocked     bool
+}
+
+// NewContentFilter adalah constructor untuk bikin instance ContentFilter
+// maskBlocked true = blocked words diganti "*", false = text ditolak dengan ValidationError
+func NewContentFilter(maxReviewLength int, blockedWords []string, maskBlocked bool) *ContentFilter {
+	filter := &ContentFilter{
+		maxReviewLength: maxReviewLength,
+		maskBlocked:     maskBlocked,
+	}
+
+	quoted := make([]string, 0, len(blockedWords))
+	for _, word := range blockedWords {
+		if word = strings.TrimSpace(word); word != "" {
+			quoted = append(quoted, regexp.QuoteMeta(word))
+		}
+	}
+	if len(quoted) > 0 {
+		filter.blockedPattern = regexp.MustCompile(`(?i)\b(` + strings.Join(quoted, "|") + `)\b`)
+	}
+
+	return filter
+}
+
+// Clean sanitize text lalu validate panjang dan blocked words
+// field dipakai untuk pesan ValidationError (misal "review_text", "body")
+func (f *ContentFilter) Clean(field string, text string, maxLength int) (string, error) {
+	text = sanitizeText(text)
+	if text == "" {
+		return "", newValidationError("%s is required", field)
+	}
+	if len([]rune(text)) > maxLength {
+		return "", newValidationError("%s must be at most %d characters", field, maxLength)
+	}
+
+	if f.blockedPattern == nil {
+		return text, nil
+	}
+	if f.maskBlocked {
+		return f.blockedPattern.ReplaceAllStringFunc(text, func(word string) string {
+			return strings.Repeat("*", len([]rune(word)))
+		}), nil
+	}
+
+	matches := f.blockedPattern.FindAllString(text, -1)
+	if len(matches) > 0 {
+		found := make([]string, 0, len(matches))
+		seen := make(map[string]bool)
+		for _, match := range matches {
+			match = strings.ToLower(match)
+			if !seen[match] {
+				seen[match] = true
+				found = append(found, match)
+			}
+		}
+		return "", newValidationError("%s contains blocked words: %s", field, strings.Join(found, ", "))
+	}
+	return text, nil
+}
+
+// CleanReviewText adalah Clean untuk review_text dengan batas panjang dari config
+func (f *ContentFilter) CleanReviewText(text string) (string, error) {
+	return f.Clean("review_text", text, f.maxReviewLength)
+}
+
+// sanitizeText mengubah input jadi plain text:
+// 1. Hapus block <script>/<style> beserta isinya, HTML comment, dan HTML tag yang well-formed
+// 2. Hapus control characters (kecuali newline & tab) lalu trim
+// HTML entities tidak di-decode: "&lt;b&gt;" yang diketik user tetap disimpan apa adanya
+// (frontend sudah escape output)
+func sanitizeText(text string) string {
+	text = stripHTML(text)
+
+	text = strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, text)
+
+	return strings.TrimSpace(text)
+}
+
+// stripHTML menghapus block <script>/<style> beserta isinya, HTML comment, dan HTML tag lain
+// Tag dengan nama yang bukan HTML element (contoh "<y but y>") dianggap text biasa
+func stripHTML(text string) string {
+	text = scriptStyleBlockPattern.ReplaceAllString(text, "")
+	text = htmlCommentPattern.ReplaceAllString(text, "")
+	return htmlTagPattern.ReplaceAllStringFunc(text, func(tag string) string {
+		if match := htmlTagPattern.FindStringSubmatch(tag); match != nil && htmlTagNames[strings.ToLower(match[1])] {
+			return ""
+		}
+		return tag
+	})
+}
@@ -0,0 +1,78 @@
+package service
+
+import "testing"
+
+func TestSanitizeText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain text", "Great movie", "Great movie"},
+		{"less-than in prose", "Season 1<Season 2, and the finale is great", "Season 1<Season 2, and the finale is great"},
+		{"comparison", "x<y but y>z", "x<y but y>z"},
+		{"literal entities", "use &lt;b&gt; for bold", "use &lt;b&gt; for bold"},
+		{"simple tags", "<b>bold</b> and <i>italic</i>", "bold and italic"},
+		{"tag with attributes", `<a href="http://x" target='_blank'>link</a>`, "link"},
+		{"self-closing tag", "line<br/>break<br />", "linebreak"},
+		{"script block", "before<script>alert(1)</script>after", "beforeafter"},
+		{"style block", "<style type=\"text/css\">body{}</style>text", "text"},
+		{"comment", "a<!-- hidden -->b", "ab"},
+		{"unquoted attribute", "<img src=x onerror=alert(1)>pic", "pic"},
+		{"unclosed tag kept", "<b unfinished", "<b unfinished"},
+		{"control characters", "a\x00b\tc\nd", "ab\tc\nd"},
+		{"trim", "  spaced  ", "spaced"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeText(tt.in); got != tt.want {
+				t.Errorf("sanitizeText(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContentFilterClean(t *testing.T) {
+	blocked := []string{"spoilerword", "bad phrase"}
+
+	tests := []struct {
+		name      string
+		mask      bool
+		in        string
+		maxLength int
+		want      string
+		wantErr   bool
+	}{
+		{"clean text", false, "A fine film", 100, "A fine film", false},
+		{"empty after sanitize", false, "<b></b>", 100, "", true},
+		{"too long", false, "abcdef", 5, "", true},
+		{"reject blocked word", false, "this has SpoilerWord inside", 100, "", true},
+		{"reject blocked phrase", false, "what a bad phrase", 100, "", true},
+		{"blocked word inside other word allowed", false, "spoilerwords are fine", 100, "spoilerwords are fine", false},
+		{"mask blocked word", true, "this has SpoilerWord inside", 100, "this has *********** inside", false},
+		{"mask blocked phrase", true, "what a bad phrase", 100, "what a **********", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := NewContentFilter(100, blocked, tt.mask)
+			got, err := filter.Clean("body", tt.in, tt.maxLength)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Clean(%q) = %q, want error", tt.in, got)
+				}
+				if !IsValidationError(err) {
+					t.Errorf("Clean(%q) error = %v, want ValidationError", tt.in, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Clean(%q) unexpected error: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("Clean(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ValidationError menandakan input dari user tidak valid
+// Handler bisa cek dengan errors.As dan return 400 (bukan 500)
+type ValidationError struct {
+	Message string
+}
+
+// Error implement interface error
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// newValidationError membuat ValidationError dengan format seperti fmt.Sprintf
+func newValidationError(format string, args ...interface{}) error {
+	return &ValidationError{Message: fmt.Sprintf(format, args...)}
+}
+
+// IsValidationError return true kalau err (atau error yang di-wrap) adalah ValidationError
+func IsValidationError(err error) bool {
+	var vErr *ValidationError
+	return errors.As(err, &vErr)
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"film-dashboard-api/internal/models"
+	"film-dashboard-api/internal/repository"
+)
+
+var (
+	// ErrOwnReviewReport dikembalikan kalau user mencoba melaporkan review miliknya sendiri
+	ErrOwnReviewReport = errors.New("you cannot report your own review")
+	// ErrSelfModeration dikembalikan kalau moderator mencoba warn/suspend dirinya sendiri
+	ErrSelfModeration = errors.New("you cannot moderate your own account")
+)
+
+// maxReportDetailsLength sama dengan panjang kolom ReviewReports.details
+const maxReportDetailsLength = 1000
+
+// maxModerationReasonLength sama dengan panjang kolom ModerationActions.reason
+const maxModerationReasonLength = 500
+
+// ModerationService adalah service untuk handle review reporting dan moderation queue
+type ModerationService struct {
+	moderationRepo  *repository.ModerationRepository
+	reviewRepo      *repository.ReviewRepository
+	communityScores *CommunityScoreService
+}
+
+// NewModerationService adalah constructor untuk bikin instance ModerationService
+// communityScores di-refresh setiap review di-hide / di-restore / dihapus (review hidden tidak dihitung)
+func NewModerationService(moderationRepo *repository.ModerationRepository, reviewRepo *repository.ReviewRepository, communityScores *CommunityScoreService) *ModerationService {
+	return &ModerationService{
+		moderationRepo:  moderationRepo,
+		reviewRepo:      reviewRepo,
+		communityScores: communityScores,
+	}
+}
+
+// ReportReview menyimpan laporan user terhadap review user lain
+// Business logic:
+// 1. Validate reason (salah satu models.ReportReasons) dan details (max 1000 karakter)
+// 2. Pastikan review ada dan bukan milik user sendiri
+// 3. Simpan report (1 report per user per review)
+func (s *ModerationService) ReportReview(userID int, reviewID int, req models.ReviewReportRequest) (*models.ReviewReport, error) {
+	reason := strings.ToLower(strings.TrimSpace(req.Reason))
+	validReason := false
+	for _, r := range models.ReportReasons {
+		if reason == r {
+			validReason = true
+			break
+		}
+	}
+	if !validReason {
+		return nil, newValidationError("reason must be one of: %s", strings.Join(models.ReportReasons, ", "))
+	}
+
+	details, err := normalizeOptionalText("details", req.Details, maxReportDetailsLength)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerID, err := s.reviewRepo.GetReviewOwner(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if ownerID == userID {
+		return nil, ErrOwnReviewReport
+	}
+
+	return s.moderationRepo.CreateReport(reviewID, userID, reason, details)
+}
+
+// GetReports mengambil moderation queue secara paged
+// status: open (default), resolved, dismissed, atau all
+func (s *ModerationService) GetReports(status string, page int, limit int) ([]*models.ModerationReport, int, error) {
+	switch status {
+	case models.ReportStatusOpen, models.ReportStatusResolved, models.ReportStatusDismissed:
+	case "all":
+		status = ""
+	default:
+		return nil, 0, newValidationError("status must be one of: open, resolved, dismissed, all")
+	}
+
+	reports, total, err := s.moderationRepo.GetReports(status, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get reports: %w", err)
+	}
+	return reports, total, nil
+}
+
+// HideReview menyembunyikan review dari public listings dan resolve semua open reports-nya
+func (s *ModerationService) HideReview(moderatorID int, reviewID int, req models.ModerationActionRequest) (*models.ModerationAction, error) {
+	reason, err := normalizeOptionalText("reason", req.Reason, maxModerationReasonLength)
+	if err != nil {
+		return nil, err
+	}
+	return s.refreshAfter(reviewID, func() (*models.ModerationAction, error) {
+		return s.moderationRepo.SetReviewHidden(reviewID, moderatorID, true, reason)
+	})
+}
+
+// RestoreReview menampilkan kembali review yang di-hide dan dismiss semua open reports-nya
+func (s *ModerationService) RestoreReview(moderatorID int, reviewID int, req models.ModerationActionRequest) (*models.ModerationAction, error) {
+	reason, err := normalizeOptionalText("reason", req.Reason, maxModerationReasonLength)
+	if err != nil {
+		return nil, err
+	}
+	return s.refreshAfter(reviewID, func() (*models.ModerationAction, error) {
+		return s.moderationRepo.SetReviewHidden(reviewID, moderatorID, false, reason)
+	})
+}
+
+// DeleteReview menghapus review secara permanen
+func (s *ModerationService) DeleteReview(moderatorID int, reviewID int, req models.ModerationActionRequest) (*models.ModerationAction, error) {
+	reason, err := normalizeOptionalText("reason", req.Reason, maxModerationReasonLength)
+	if err != nil {
+		return nil, err
+	}
+	return s.refreshAfter(reviewID, func() (*models.ModerationAction, error) {
+		return s.moderationRepo.DeleteReview(reviewID, moderatorID, reason)
+	})
+}
+
+// DismissReport menolak satu laporan tanpa mengubah review
+func (s *ModerationService) DismissReport(moderatorID int, reportID int, req models.ModerationActionRequest) (*models.ModerationAction, error) {
+	reason, err := normalizeOptionalText("reason", req.Reason, maxModerationReasonLength)
+	if err != nil {
+		return nil, err
+	}
+	return s.moderationRepo.DismissReport(reportID, moderatorID, reason)
+}
+
+// ModerateUser menjalankan warn / suspend / reinstate terhadap author review
+// action: models.ModerationWarnUser, models.ModerationSuspendUser, atau models.ModerationReinstateUser
+func (s *ModerationService) ModerateUser(moderatorID int, userID int, action string, req models.ModerationActionRequest) (*models.ModerationAction, error) {
+	switch action {
+	case models.ModerationWarnUser, models.ModerationSuspendUser, models.ModerationReinstateUser:
+	default:
+		return nil, fmt.Errorf("unknown user moderation action %q", action)
+	}
+	if userID == moderatorID {
+		return nil, ErrSelfModeration
+	}
+
+	reason, err := normalizeOptionalText("reason", req.Reason, maxModerationReasonLength)
+	if err != nil {
+		return nil, err
+	}
+	return s.moderationRepo.ModerateUser(userID, moderatorID, action, reason)
+}
+
+// refreshAfter menjalankan review action lalu refresh community score title review tersebut
+// (title diambil sebelum action karena review bisa sudah dihapus setelahnya)
+func (s *ModerationService) refreshAfter(reviewID int, action func() (*models.ModerationAction, error)) (*models.ModerationAction, error) {
+	titleID := ""
+	if review, err := s.reviewRepo.GetReviewByID(0, reviewID); err == nil {
+		titleID = review.TitleID
+	}
+
+	result, err := action()
+	if err != nil {
+		return nil, err
+	}
+
+	s.communityScores.Refresh(titleID)
+	return result, nil
+}
+
+// normalizeOptionalText trim text optional, string kosong jadi nil, dan validate panjangnya
+func normalizeOptionalText(field string, value *string, maxLength int) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil, nil
+	}
+	if len([]rune(trimmed)) > maxLength {
+		return nil, newValidationError("%s must be at most %d characters", field, maxLength)
+	}
+	return &trimmed, nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"fmt"
+
+	"film-dashboard-api/internal/models"
+	"film-dashboard-api/internal/repository"
+)
+
+// maxCommentLength sama dengan panjang kolom ReviewComments.body
+const maxCommentLength = 2000
+
+// CreateComment menambahkan comment ke review (reply kalau req.ParentCommentID diisi)
+// Return repository.ErrReviewNotFound (termasuk review yang di-hide) / repository.ErrCommentNotFound (parent) sesuai kondisi
+func (s *ReviewService) CreateComment(userID int, reviewID int, req models.ReviewCommentRequest) (*models.ReviewComment, error) {
+	body, err := s.contentFilter.Clean("body", req.Body, maxCommentLength)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reviewRepo.CreateComment(reviewID, userID, req.ParentCommentID, body)
+}
+
+// UpdateComment mengubah body comment milik user
+// Return repository.ErrCommentNotFound / repository.ErrCommentForbidden sesuai kondisi
+func (s *ReviewService) UpdateComment(userID int, commentID int, req models.ReviewCommentRequest) (*models.ReviewComment, error) {
+	body, err := s.contentFilter.Clean("body", req.Body, maxCommentLength)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reviewRepo.UpdateComment(commentID, userID, body)
+}
+
+// DeleteComment soft-delete comment milik user
+// Return repository.ErrCommentNotFound / repository.ErrCommentForbidden sesuai kondisi
+func (s *ReviewService) DeleteComment(userID int, commentID int) error {
+	return s.reviewRepo.DeleteComment(commentID, userID)
+}
+
+// GetComments mengambil comments sebuah review secara paged
+// parentID nil = top-level comments, selain itu replies dari comment tersebut
+// Review yang di-hide moderator hanya bisa dibaca comments-nya oleh author dan moderator
+// (viewerID 0 = tanpa login)
+// Return repository.ErrReviewNotFound kalau review tidak ada / di-hide untuk viewer ini
+func (s *ReviewService) GetComments(viewerID int, isModerator bool, reviewID int, parentID *int, page int, limit int) ([]*models.ReviewComment, int, error) {
+	ownerID, isHidden, err := s.reviewRepo.GetReviewVisibility(reviewID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if isHidden && !isModerator && (viewerID == 0 || viewerID != ownerID) {
+		return nil, 0, repository.ErrReviewNotFound
+	}
+
+	comments, total, err := s.reviewRepo.GetComments(reviewID, parentID, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get comments: %w", err)
+	}
+	return comments, total, nil
+}
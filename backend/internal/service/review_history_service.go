@@ -0,0 +1,35 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"film-dashboard-api/internal/models"
+)
+
+// ErrReviewHistoryForbidden dikembalikan kalau user bukan author review dan bukan moderator
+var ErrReviewHistoryForbidden = errors.New("only the review author or a moderator can view its edit history")
+
+// GetReviewHistory mengambil versi sekarang dan semua versi lama sebuah review
+// Hanya author review atau moderator (isModerator) yang boleh melihat
+// Return repository.ErrReviewNotFound / ErrReviewHistoryForbidden sesuai kondisi
+func (s *ReviewService) GetReviewHistory(viewerID int, isModerator bool, reviewID int) (*models.ReviewHistoryResponse, error) {
+	current, err := s.reviewRepo.GetReviewByID(viewerID, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if current.UserID != viewerID && !isModerator {
+		return nil, ErrReviewHistoryForbidden
+	}
+
+	revisions, err := s.reviewRepo.GetReviewHistory(reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review history: %w", err)
+	}
+
+	return &models.ReviewHistoryResponse{
+		ReviewID:  reviewID,
+		Current:   current,
+		Revisions: revisions,
+	}, nil
+}
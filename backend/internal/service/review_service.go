@@ -8,89 +8,156 @@ import (
 	"film-dashboard-api/internal/repository"
 )
 
+// ErrOwnReviewVote dikembalikan kalau user mencoba vote review miliknya sendiri
+var ErrOwnReviewVote = errors.New("you cannot vote on your own review")
+
 // ReviewService adalah service untuk handle review operations
 type ReviewService struct {
-	reviewRepo *repository.ReviewRepository
+	reviewRepo          *repository.ReviewRepository
+	contentFilter       *ContentFilter
+	allowEpisodeReviews bool
+	communityScores     *CommunityScoreService
 }
 
 // NewReviewService adalah constructor untuk bikin instance ReviewService
-func NewReviewService(reviewRepo *repository.ReviewRepository) *ReviewService {
+// contentFilter dipakai untuk sanitize review_text & comment body
+// allowEpisodeReviews false = review untuk episode ditolak (hanya movie / series)
+// communityScores di-refresh setiap review dibuat / diubah / dihapus
+func NewReviewService(reviewRepo *repository.ReviewRepository, contentFilter *ContentFilter, allowEpisodeReviews bool, communityScores *CommunityScoreService) *ReviewService {
 	return &ReviewService{
-		reviewRepo: reviewRepo,
+		reviewRepo:          reviewRepo,
+		contentFilter:       contentFilter,
+		allowEpisodeReviews: allowEpisodeReviews,
+		communityScores:     communityScores,
 	}
 }
 
 // CreateOrUpdateReview membuat atau update review user
 // Business logic:
 // 1. Validate input (rating 1-10, review_text tidak boleh kosong)
-// 2. Call repository untuk create/update
-// 3. Return review response
+// 2. Sanitize review_text (strip HTML/script, max length, blocked words)
+// 3. Pastikan title ada di catalog (repository.ErrTitleNotFound) dan bukan episode (kecuali diizinkan)
+// 4. Call repository untuk create/update, lalu refresh community score title
+// 5. Return review response
 func (s *ReviewService) CreateOrUpdateReview(userID int, req models.ReviewRequest) (*models.ReviewResponse, error) {
 	// 1. Validate input
 	if req.TitleID == "" {
-		return nil, errors.New("title_id is required")
+		return nil, newValidationError("title_id is required")
 	}
 
 	if req.Rating < 1 || req.Rating > 10 {
-		return nil, errors.New("rating must be between 1 and 10")
+		return nil, newValidationError("rating must be between 1 and 10")
+	}
+
+	// 2. Sanitize review text
+	reviewText, err := s.contentFilter.CleanReviewText(req.ReviewText)
+	if err != nil {
+		return nil, err
 	}
 
-	if req.ReviewText == "" {
-		return nil, errors.New("review_text is required")
+	// 3. Validate review target
+	isEpisode, err := s.reviewRepo.GetReviewTarget(req.TitleID)
+	if err != nil {
+		return nil, err
+	}
+	if isEpisode && !s.allowEpisodeReviews {
+		return nil, newValidationError("reviews on individual episodes are not allowed, review the series instead")
 	}
 
-	// 2. Create or update review via repository
-	review, err := s.reviewRepo.CreateOrUpdateReview(userID, req.TitleID, req.Rating, req.ReviewText)
+	// 4. Create or update review via repository
+	review, err := s.reviewRepo.CreateOrUpdateReview(userID, req.TitleID, req.Rating, reviewText, req.ContainsSpoilers)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create/update review: %w", err)
 	}
+	s.communityScores.Refresh(review.TitleID)
 
-	// 3. Convert to response
+	// 5. Convert to response
 	response := &models.ReviewResponse{
-		ReviewID:   review.ReviewID,
-		UserID:     review.UserID,
-		TitleID:    review.TitleID,
-		Rating:     review.Rating,
-		ReviewText: review.ReviewText,
-		CreatedAt:  review.CreatedAt,
-		UpdatedAt:  review.UpdatedAt,
+		ReviewID:         review.ReviewID,
+		UserID:           review.UserID,
+		TitleID:          review.TitleID,
+		Rating:           review.Rating,
+		ReviewText:       review.ReviewText,
+		ContainsSpoilers: review.ContainsSpoilers,
+		CreatedAt:        review.CreatedAt,
+		UpdatedAt:        review.UpdatedAt,
+		IsEdited:         review.EditedAt != nil,
+		EditedAt:         review.EditedAt,
 	}
 
 	return response, nil
 }
 
-// GetReviewsByTitle mengambil semua review untuk sebuah title
-func (s *ReviewService) GetReviewsByTitle(titleID string) ([]models.ReviewResponse, error) {
+// reviewSortOptions adalah sort option yang valid untuk listing review
+var reviewSortOptions = map[string]bool{
+	"newest":  true,
+	"oldest":  true,
+	"highest": true,
+	"lowest":  true,
+	"helpful": true,
+}
+
+// validateReviewSort return ValidationError kalau sort tidak dikenal
+func validateReviewSort(sort string) error {
+	if !reviewSortOptions[sort] {
+		return newValidationError("unknown sort %q (valid: newest, oldest, highest, lowest, helpful)", sort)
+	}
+	return nil
+}
+
+// redactSpoilers mengosongkan review_text review yang ditandai spoiler
+// kecuali showSpoilers true atau review milik viewer sendiri
+func redactSpoilers(reviews []models.ReviewResponse, viewerID int, showSpoilers bool) {
+	if showSpoilers {
+		return
+	}
+	for i := range reviews {
+		if reviews[i].ContainsSpoilers && reviews[i].UserID != viewerID {
+			reviews[i].ReviewText = ""
+			reviews[i].SpoilerRedacted = true
+		}
+	}
+}
+
+// GetReviewsByTitle mengambil review untuk sebuah title secara paged beserta rating summary
+// viewerID = user yang sedang login untuk MyVote (0 = tanpa login)
+// showSpoilers false = review_text spoiler di-redact (kecuali milik viewer)
+// Summary hanya menghitung review publik; total untuk pagination ikut review hidden milik viewer
+func (s *ReviewService) GetReviewsByTitle(viewerID int, titleID string, sort string, showSpoilers bool, page int, limit int) ([]models.ReviewResponse, *models.RatingSummary, int, error) {
 	if titleID == "" {
-		return nil, errors.New("title_id is required")
+		return nil, nil, 0, newValidationError("title_id is required")
+	}
+	if err := validateReviewSort(sort); err != nil {
+		return nil, nil, 0, err
 	}
 
-	reviews, err := s.reviewRepo.GetReviewsByTitle(titleID)
+	summary, err := s.reviewRepo.GetRatingSummary(titleID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get reviews: %w", err)
+		return nil, nil, 0, fmt.Errorf("failed to get rating summary: %w", err)
 	}
 
-	// Return empty array jika tidak ada review
-	if reviews == nil {
-		reviews = []models.ReviewResponse{}
+	reviews, total, err := s.reviewRepo.GetReviewsByTitle(viewerID, titleID, sort, page, limit)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to get reviews: %w", err)
 	}
+	redactSpoilers(reviews, viewerID, showSpoilers)
 
-	return reviews, nil
+	return reviews, summary, total, nil
 }
 
-// GetReviewsByUser mengambil semua review yang ditulis user
-func (s *ReviewService) GetReviewsByUser(userID int) ([]models.ReviewResponse, error) {
-	reviews, err := s.reviewRepo.GetReviewsByUser(userID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user reviews: %w", err)
+// GetReviewsByUser mengambil review yang ditulis user secara paged
+// Return: reviews untuk page yang diminta, total review user, dan error
+func (s *ReviewService) GetReviewsByUser(userID int, sort string, page int, limit int) ([]models.ReviewResponse, int, error) {
+	if err := validateReviewSort(sort); err != nil {
+		return nil, 0, err
 	}
 
-	// Return empty array jika tidak ada review
-	if reviews == nil {
-		reviews = []models.ReviewResponse{}
+	reviews, total, err := s.reviewRepo.GetReviewsByUser(userID, sort, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get user reviews: %w", err)
 	}
 
-	return reviews, nil
+	return reviews, total, nil
 }
 
 // DeleteReview menghapus review (hanya owner yang bisa delete)
@@ -99,12 +166,19 @@ func (s *ReviewService) DeleteReview(reviewID int, userID int) error {
 		return errors.New("review_id is required")
 	}
 
+	// Title review diambil dulu untuk refresh community score setelah delete
+	titleID := ""
+	if review, err := s.reviewRepo.GetReviewByID(0, reviewID); err == nil {
+		titleID = review.TitleID
+	}
+
 	// Repository akan verify ownership
 	err := s.reviewRepo.DeleteReview(reviewID, userID)
 	if err != nil {
 		return err
 	}
 
+	s.communityScores.Refresh(titleID)
 	return nil
 }
 
@@ -121,3 +195,58 @@ func (s *ReviewService) GetUserReviewForTitle(userID int, titleID string) (*mode
 
 	return review, nil
 }
+
+// VoteReview menyimpan vote helpful / not helpful user untuk review milik user lain
+// Business logic:
+// 1. Validate vote ("helpful" / "not_helpful")
+// 2. Pastikan review ada dan bukan milik user sendiri
+// 3. Upsert vote (1 vote per user per review) dan return total vote terbaru
+func (s *ReviewService) VoteReview(userID int, reviewID int, req models.ReviewVoteRequest) (*models.ReviewVoteSummary, error) {
+	var isHelpful bool
+	switch req.Vote {
+	case models.ReviewVoteHelpful:
+		isHelpful = true
+	case models.ReviewVoteNotHelpful:
+		isHelpful = false
+	default:
+		return nil, newValidationError("vote must be %q or %q", models.ReviewVoteHelpful, models.ReviewVoteNotHelpful)
+	}
+
+	if err := s.ensureVotable(userID, reviewID); err != nil {
+		return nil, err
+	}
+
+	if err := s.reviewRepo.SetReviewVote(reviewID, userID, isHelpful); err != nil {
+		return nil, err
+	}
+	return s.reviewRepo.GetReviewVoteSummary(reviewID, userID)
+}
+
+// RemoveReviewVote menghapus vote user untuk sebuah review
+// Idempotent - review yang belum di-vote tetap return success
+func (s *ReviewService) RemoveReviewVote(userID int, reviewID int) (*models.ReviewVoteSummary, error) {
+	if err := s.ensureVotable(userID, reviewID); err != nil {
+		return nil, err
+	}
+
+	if err := s.reviewRepo.DeleteReviewVote(reviewID, userID); err != nil {
+		return nil, err
+	}
+	return s.reviewRepo.GetReviewVoteSummary(reviewID, userID)
+}
+
+// ensureVotable return repository.ErrReviewNotFound / ErrOwnReviewVote sesuai kondisi
+// Review yang di-hide moderator dianggap tidak ada (tidak bisa di-vote)
+func (s *ReviewService) ensureVotable(userID int, reviewID int) error {
+	ownerID, isHidden, err := s.reviewRepo.GetReviewVisibility(reviewID)
+	if err != nil {
+		return err
+	}
+	if isHidden {
+		return repository.ErrReviewNotFound
+	}
+	if ownerID == userID {
+		return ErrOwnReviewVote
+	}
+	return nil
+}
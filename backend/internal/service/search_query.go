@@ -0,0 +1,291 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"film-dashboard-api/internal/models"
+)
+
+// Structured search query syntax (dipakai oleh search box):
+//
+//	genre:drama year:2010..2015 rating:>8 type:series "breaking"
+//
+// - key:value       filter (genre, type, status, country, year, rating, runtime, votes, adult, inproduction, sort)
+// - key:a,b         OR di dalam satu facet (genre:drama,comedy)
+// - key:"a b"       value dengan spasi
+// - range           a..b, a.., ..b, >a, >=a, <a, <=a, atau a (exact)
+// - kata / "frasa"  full-text search (digabung jadi FilterRequest.Q)
+//
+// Word yang langsung diikuti ':' tapi key-nya tidak dikenal adalah error (typo seperti "gnre:drama"
+// tidak diam-diam jadi full-text). Untuk teks yang memang mengandung ':', pakai kutip: "Star Wars: Episode"
+
+// searchClause adalah satu key:value di query
+// keyPos dan valuePos adalah posisi karakter (0-based) untuk error reporting
+type searchClause struct {
+	key      string
+	value    string
+	keyPos   int
+	valuePos int
+}
+
+// parsedSearchQuery adalah hasil parse sebelum nama option di-resolve ke ID
+type parsedSearchQuery struct {
+	text    []string
+	clauses []searchClause
+}
+
+// searchQueryKeys mapping key (dan alias-nya) ke nama canonical
+var searchQueryKeys = map[string]string{
+	"genre":         "genre",
+	"genres":        "genre",
+	"type":          "type",
+	"status":        "status",
+	"country":       "country",
+	"origin":        "country",
+	"year":          "year",
+	"rating":        "rating",
+	"runtime":       "runtime",
+	"votes":         "votes",
+	"adult":         "adult",
+	"inproduction":  "inproduction",
+	"in_production": "inproduction",
+	"sort":          "sort",
+	"sortby":        "sort",
+}
+
+// validSearchQueryKeys adalah daftar key canonical (urut) untuk pesan error unknown key
+var validSearchQueryKeys = func() string {
+	seen := make(map[string]bool)
+	keys := make([]string, 0, len(searchQueryKeys))
+	for _, canonical := range searchQueryKeys {
+		if !seen[canonical] {
+			seen[canonical] = true
+			keys = append(keys, canonical)
+		}
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ", ")
+}()
+
+// searchQuerySorts adalah value yang valid untuk sort:
+var searchQuerySorts = map[string]bool{
+	"relevance":  true,
+	"popularity": true,
+	"rating":     true,
+	"votes":      true,
+	"released":   true,
+	"name":       true,
+	"community":  true,
+}
+
+// queryError membuat SearchQueryError
+func queryError(pos int, token string, format string, args ...interface{}) *models.SearchQueryError {
+	return &models.SearchQueryError{
+		Position: pos,
+		Token:    token,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// parseSearchQuery memecah query jadi teks bebas dan key:value clauses
+func parseSearchQuery(input string) (*parsedSearchQuery, error) {
+	runes := []rune(input)
+	parsed := &parsedSearchQuery{}
+
+	i := 0
+	for i < len(runes) {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		// 1. Frasa dalam tanda kutip -> full-text
+		if runes[i] == '"' {
+			phrase, next, err := readQuoted(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			if phrase = strings.TrimSpace(phrase); phrase != "" {
+				parsed.text = append(parsed.text, phrase)
+			}
+			i = next
+			continue
+		}
+
+		// 2. Word atau key:value
+		start := i
+		for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != ':' && runes[i] != '"' {
+			i++
+		}
+		word := string(runes[start:i])
+
+		key, known := searchQueryKeys[strings.ToLower(word)]
+		if i < len(runes) && runes[i] == ':' && word != "" && !known {
+			return nil, queryError(start, word+":", "unknown key %q (valid: %s)", word, validSearchQueryKeys)
+		}
+		if i < len(runes) && runes[i] == ':' && known {
+			i++ // skip ':'
+			valuePos := i
+			var value string
+			if i < len(runes) && runes[i] == '"' {
+				quoted, next, err := readQuoted(runes, i)
+				if err != nil {
+					return nil, err
+				}
+				value, i = quoted, next
+				valuePos++ // posisi value = setelah kutip pembuka
+				if i < len(runes) && !unicode.IsSpace(runes[i]) {
+					return nil, queryError(i, string(runes[i]), "expected space after quoted value")
+				}
+			} else {
+				for i < len(runes) && !unicode.IsSpace(runes[i]) {
+					i++
+				}
+				value = string(runes[valuePos:i])
+			}
+			if strings.TrimSpace(value) == "" {
+				return nil, queryError(valuePos, word+":", "missing value for %q", word)
+			}
+			parsed.clauses = append(parsed.clauses, searchClause{key: key, value: strings.TrimSpace(value), keyPos: start, valuePos: valuePos})
+			continue
+		}
+
+		// Bukan key:value: ambil sampai spasi sebagai teks biasa
+		for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '"' {
+			i++
+		}
+		parsed.text = append(parsed.text, string(runes[start:i]))
+	}
+
+	return parsed, nil
+}
+
+// readQuoted membaca string dalam tanda kutip mulai dari runes[start] == '"'
+// Return isi (tanpa kutip) dan posisi setelah kutip penutup
+func readQuoted(runes []rune, start int) (string, int, error) {
+	for i := start + 1; i < len(runes); i++ {
+		if runes[i] == '"' {
+			return string(runes[start+1 : i]), i + 1, nil
+		}
+	}
+	return "", 0, queryError(start, string(runes[start:]), "unterminated quote")
+}
+
+// rangeBound adalah satu sisi range (exclusive untuk > dan <)
+type rangeBound struct {
+	value     string
+	exclusive bool
+}
+
+// parseRange membaca range value: a..b, a.., ..b, >a, >=a, <a, <=a, atau a
+func parseRange(c searchClause) (lo, hi *rangeBound, err error) {
+	v := c.value
+	switch {
+	case strings.Contains(v, ".."):
+		parts := strings.SplitN(v, "..", 2)
+		if parts[0] != "" {
+			lo = &rangeBound{value: parts[0]}
+		}
+		if parts[1] != "" {
+			hi = &rangeBound{value: parts[1]}
+		}
+		if lo == nil && hi == nil {
+			return nil, nil, queryError(c.valuePos, v, "range for %q needs at least one bound", c.key)
+		}
+	case strings.HasPrefix(v, ">="):
+		lo = &rangeBound{value: v[2:]}
+	case strings.HasPrefix(v, ">"):
+		lo = &rangeBound{value: v[1:], exclusive: true}
+	case strings.HasPrefix(v, "<="):
+		hi = &rangeBound{value: v[2:]}
+	case strings.HasPrefix(v, "<"):
+		hi = &rangeBound{value: v[1:], exclusive: true}
+	default:
+		lo = &rangeBound{value: v}
+		hi = &rangeBound{value: v}
+	}
+	return lo, hi, nil
+}
+
+// intRange parse range sebagai integer (exclusive bound digeser 1)
+func intRange(c searchClause) (*int, *int, error) {
+	lo, hi, err := parseRange(c)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	convert := func(b *rangeBound, shift int) (*int, error) {
+		if b == nil {
+			return nil, nil
+		}
+		n, err := strconv.Atoi(b.value)
+		if err != nil {
+			return nil, queryError(c.valuePos, c.value, "%q expects a whole number, got %q", c.key, b.value)
+		}
+		if b.exclusive {
+			n += shift
+		}
+		return &n, nil
+	}
+
+	from, err := convert(lo, 1)
+	if err != nil {
+		return nil, nil, err
+	}
+	to, err := convert(hi, -1)
+	if err != nil {
+		return nil, nil, err
+	}
+	return from, to, nil
+}
+
+// floatRange parse range sebagai float (exclusive bound digeser ke float terdekat)
+func floatRange(c searchClause) (*float64, *float64, error) {
+	lo, hi, err := parseRange(c)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	convert := func(b *rangeBound, direction float64) (*float64, error) {
+		if b == nil {
+			return nil, nil
+		}
+		f, err := strconv.ParseFloat(b.value, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, queryError(c.valuePos, c.value, "%q expects a number, got %q", c.key, b.value)
+		}
+		if b.exclusive {
+			f = math.Nextafter(f, direction)
+		}
+		return &f, nil
+	}
+
+	from, err := convert(lo, math.Inf(1))
+	if err != nil {
+		return nil, nil, err
+	}
+	to, err := convert(hi, math.Inf(-1))
+	if err != nil {
+		return nil, nil, err
+	}
+	return from, to, nil
+}
+
+// parseQueryBool menerima true/false, yes/no, 1/0
+func parseQueryBool(c searchClause) (*bool, error) {
+	var b bool
+	switch strings.ToLower(c.value) {
+	case "true", "yes", "1":
+		b = true
+	case "false", "no", "0":
+		b = false
+	default:
+		return nil, queryError(c.valuePos, c.value, "%q expects true or false", c.key)
+	}
+	return &b, nil
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"film-dashboard-api/internal/models"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		wantText    []string
+		wantClauses []searchClause
+	}{
+		{"empty", "   ", nil, nil},
+		{"plain words", "star wars", []string{"star", "wars"}, nil},
+		{"quoted phrase", `"breaking bad"`, []string{"breaking bad"}, nil},
+		{"quoted phrase with colon", `"Star Wars: Episode"`, []string{"Star Wars: Episode"}, nil},
+		{
+			"key value", "genre:drama",
+			nil,
+			[]searchClause{{key: "genre", value: "drama", keyPos: 0, valuePos: 6}},
+		},
+		{
+			"alias and case", "Origin:US",
+			nil,
+			[]searchClause{{key: "country", value: "US", keyPos: 0, valuePos: 7}},
+		},
+		{
+			"quoted value", `status:"in production" foo`,
+			[]string{"foo"},
+			[]searchClause{{key: "status", value: "in production", keyPos: 0, valuePos: 8}},
+		},
+		{
+			"mixed", `genre:drama,comedy year:2010..2015 "the wire" rating:>8`,
+			[]string{"the wire"},
+			[]searchClause{
+				{key: "genre", value: "drama,comedy", keyPos: 0, valuePos: 6},
+				{key: "year", value: "2010..2015", keyPos: 19, valuePos: 24},
+				{key: "rating", value: ">8", keyPos: 46, valuePos: 53},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSearchQuery(tt.in)
+			if err != nil {
+				t.Fatalf("parseSearchQuery(%q) unexpected error: %v", tt.in, err)
+			}
+			if !reflect.DeepEqual(got.text, tt.wantText) {
+				t.Errorf("text = %#v, want %#v", got.text, tt.wantText)
+			}
+			if !reflect.DeepEqual(got.clauses, tt.wantClauses) {
+				t.Errorf("clauses = %#v, want %#v", got.clauses, tt.wantClauses)
+			}
+		})
+	}
+}
+
+func TestParseSearchQueryErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        string
+		wantPos   int
+		wantToken string
+	}{
+		{"unknown key", "gnre:drama", 0, "gnre:"},
+		{"unknown key after text", "star wars: episode", 5, "wars:"},
+		{"unknown short key", "x a:b", 2, "a:"},
+		{"unterminated phrase", `foo "bar`, 4, `"bar`},
+		{"unterminated quoted value", `genre:"drama`, 6, `"drama`},
+		{"missing value", "year: 2010", 5, "year:"},
+		{"missing value at end", "foo rating:", 11, "rating:"},
+		{"no space after quoted value", `genre:"drama"x`, 13, "x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSearchQuery(tt.in)
+			var qerr *models.SearchQueryError
+			if !errors.As(err, &qerr) {
+				t.Fatalf("parseSearchQuery(%q) error = %v, want *SearchQueryError", tt.in, err)
+			}
+			if qerr.Position != tt.wantPos || qerr.Token != tt.wantToken {
+				t.Errorf("parseSearchQuery(%q) error at %d %q, want %d %q", tt.in, qerr.Position, qerr.Token, tt.wantPos, tt.wantToken)
+			}
+		})
+	}
+}
+
+func intPtr(n int) *int { return &n }
+
+func TestIntRange(t *testing.T) {
+	tests := []struct {
+		value    string
+		wantFrom *int
+		wantTo   *int
+	}{
+		{"2010..2015", intPtr(2010), intPtr(2015)},
+		{"2010..", intPtr(2010), nil},
+		{"..2015", nil, intPtr(2015)},
+		{">2010", intPtr(2011), nil},
+		{">=2010", intPtr(2010), nil},
+		{"<2015", nil, intPtr(2014)},
+		{"<=2015", nil, intPtr(2015)},
+		{"2012", intPtr(2012), intPtr(2012)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			from, to, err := intRange(searchClause{key: "year", value: tt.value})
+			if err != nil {
+				t.Fatalf("intRange(%q) unexpected error: %v", tt.value, err)
+			}
+			if !reflect.DeepEqual(from, tt.wantFrom) || !reflect.DeepEqual(to, tt.wantTo) {
+				t.Errorf("intRange(%q) = %v..%v, want %v..%v", tt.value, deref(from), deref(to), deref(tt.wantFrom), deref(tt.wantTo))
+			}
+		})
+	}
+}
+
+func deref(n *int) interface{} {
+	if n == nil {
+		return nil
+	}
+	return *n
+}
+
+func TestFloatRange(t *testing.T) {
+	from, to, err := floatRange(searchClause{key: "rating", value: ">8"})
+	if err != nil {
+		t.Fatalf("floatRange(>8) unexpected error: %v", err)
+	}
+	if from == nil || *from <= 8 || to != nil {
+		t.Errorf("floatRange(>8) = %v..%v, want exclusive lower bound above 8", from, to)
+	}
+
+	from, to, err = floatRange(searchClause{key: "rating", value: "7.5..9"})
+	if err != nil {
+		t.Fatalf("floatRange(7.5..9) unexpected error: %v", err)
+	}
+	if from == nil || *from != 7.5 || to == nil || *to != 9 {
+		t.Errorf("floatRange(7.5..9) = %v..%v, want 7.5..9", from, to)
+	}
+}
+
+func TestRangeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"no bounds", ".."},
+		{"not a number", "abc"},
+		{"bad upper bound", "2010..x"},
+		{"float for int", "2010.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := intRange(searchClause{key: "year", value: tt.value, valuePos: 5})
+			var qerr *models.SearchQueryError
+			if !errors.As(err, &qerr) {
+				t.Fatalf("intRange(%q) error = %v, want *SearchQueryError", tt.value, err)
+			}
+			if qerr.Position != 5 {
+				t.Errorf("intRange(%q) error position = %d, want 5", tt.value, qerr.Position)
+			}
+		})
+	}
+
+	if _, _, err := floatRange(searchClause{key: "rating", value: "NaN"}); err == nil {
+		t.Error("floatRange(NaN) expected error")
+	}
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"film-dashboard-api/internal/models"
+	"film-dashboard-api/internal/repository"
+)
+
+// SearchService compile structured search query (lihat search_query.go) ke FilterRequest
+// Hasilnya dijalankan oleh TitleRepository.FilterTitles, jadi semua value tetap di-bind sebagai parameter
+type SearchService struct {
+	titleRepo *repository.TitleRepository
+}
+
+// NewSearchService adalah constructor untuk bikin instance SearchService
+func NewSearchService(titleRepo *repository.TitleRepository) *SearchService {
+	return &SearchService{
+		titleRepo: titleRepo,
+	}
+}
+
+// CompileQuery parse query dan apply hasilnya ke filter
+// - Teks bebas & frasa -> filter.Q (full-text)
+// - genre/type/status/country -> ID di-resolve dari lookup table, ditambahkan ke filter
+// - year/rating/runtime/votes -> range filters, adult/inproduction -> toggles, sort -> SortBy
+// Error syntax / value dikembalikan sebagai *models.SearchQueryError (dengan posisi)
+func (s *SearchService) CompileQuery(query string, filter *models.FilterRequest) error {
+	parsed, err := parseSearchQuery(query)
+	if err != nil {
+		return err
+	}
+
+	filter.Q = strings.Join(parsed.text, " ")
+
+	for _, c := range parsed.clauses {
+		switch c.key {
+		case "genre", "type", "status", "country":
+			ids, err := s.resolveIDs(c)
+			if err != nil {
+				return err
+			}
+			switch c.key {
+			case "genre":
+				filter.GenreIDs = append(filter.GenreIDs, ids...)
+			case "type":
+				filter.TypeIDs = append(filter.TypeIDs, ids...)
+			case "status":
+				filter.StatusIDs = append(filter.StatusIDs, ids...)
+			case "country":
+				filter.OriginCountryIDs = append(filter.OriginCountryIDs, ids...)
+			}
+
+		case "year":
+			if filter.YearFrom, filter.YearTo, err = intRange(c); err != nil {
+				return err
+			}
+		case "runtime":
+			if filter.RuntimeMin, filter.RuntimeMax, err = intRange(c); err != nil {
+				return err
+			}
+		case "rating":
+			if filter.RatingMin, filter.RatingMax, err = floatRange(c); err != nil {
+				return err
+			}
+		case "votes":
+			from, to, err := intRange(c)
+			if err != nil {
+				return err
+			}
+			if to != nil && (from == nil || *from != *to) {
+				return queryError(c.valuePos, c.value, "votes only supports a minimum (e.g. votes:>1000)")
+			}
+			filter.MinVotes = from
+
+		case "adult":
+			if filter.Adult, err = parseQueryBool(c); err != nil {
+				return err
+			}
+		case "inproduction":
+			if filter.InProduction, err = parseQueryBool(c); err != nil {
+				return err
+			}
+
+		case "sort":
+			sortBy := strings.ToLower(c.value)
+			if !searchQuerySorts[sortBy] {
+				return queryError(c.valuePos, c.value, "unknown sort %q", c.value)
+			}
+			filter.SortBy = sortBy
+		}
+	}
+
+	return nil
+}
+
+// resolveIDs resolve setiap nama di value (comma-separated) ke option ID
+// Nama yang tidak ditemukan dikembalikan sebagai SearchQueryError di posisi nama tersebut
+func (s *SearchService) resolveIDs(c searchClause) ([]string, error) {
+	var ids []string
+	pos := c.valuePos
+	for _, name := range strings.Split(c.value, ",") {
+		trimmed := strings.TrimSpace(name)
+		if trimmed == "" {
+			return nil, queryError(pos, c.value, "empty %s in list", c.key)
+		}
+
+		found, err := s.titleRepo.ResolveOptionIDs(c.key, trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s %q: %w", c.key, trimmed, err)
+		}
+		if len(found) == 0 {
+			return nil, queryError(pos, trimmed, "unknown %s %q", c.key, trimmed)
+		}
+		ids = append(ids, found...)
+		pos += len([]rune(name)) + 1 // +1 untuk koma
+	}
+	return ids, nil
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"film-dashboard-api/internal/models"
+	"film-dashboard-api/internal/repository"
+)
+
+// trendingWindows adalah window yang valid untuk ?window=
+// Half-life 1/4 panjang window: aktivitas terbaru mendominasi tanpa membuang aktivitas lama sama sekali
+var trendingWindows = map[string]models.TrendingWindow{
+	"day":   {Name: "day", Hours: 24, HalfLifeHours: 6},
+	"week":  {Name: "week", Hours: 24 * 7, HalfLifeHours: 42},
+	"month": {Name: "month", Hours: 24 * 30, HalfLifeHours: 24 * 7.5},
+}
+
+// DefaultTrendingWindow dipakai kalau client tidak mengirim ?window=
+const DefaultTrendingWindow = "week"
+
+// TrendingService menghitung trending dari aktivitas user (reviews, watchlist adds, detail views)
+// Score di-recompute periodik di background (lihat Start), request hanya membaca TrendingScores
+type TrendingService struct {
+	titleRepo          *repository.TitleRepository
+	viewRetentionHours int
+
+	mu           sync.RWMutex
+	refreshFails map[string]error // error refresh terakhir per window (nil = berhasil)
+}
+
+// NewTrendingService adalah constructor untuk bikin instance TrendingService
+// minViewRetentionDays = TitleViews yang harus tetap disimpan untuk pemakai lain (analytics);
+// retensi = yang lebih panjang antara itu dan window trending terpanjang
+func NewTrendingService(titleRepo *repository.TitleRepository, minViewRetentionDays int) *TrendingService {
+	retentionHours := longestTrendingWindowHours()
+	if minViewRetentionDays*24 > retentionHours {
+		retentionHours = minViewRetentionDays * 24
+	}
+	return &TrendingService{
+		titleRepo:          titleRepo,
+		viewRetentionHours: retentionHours,
+		refreshFails:       make(map[string]error),
+	}
+}
+
+// Start recompute semua window pertama kali lalu setiap interval (di background goroutine)
+// Kalau recompute gagal, score lama tetap dipakai. Setiap putaran juga menghapus TitleViews
+// yang sudah di luar retensi (lihat NewTrendingService) supaya table tidak tumbuh tanpa batas
+func (s *TrendingService) Start(interval time.Duration) {
+	go func() {
+		s.refreshAndLog()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			s.refreshAndLog()
+		}
+	}()
+}
+
+func (s *TrendingService) refreshAndLog() {
+	start := time.Now()
+	for _, name := range []string{"day", "week", "month"} {
+		count, err := s.titleRepo.RefreshTrendingScores(trendingWindows[name])
+		s.mu.Lock()
+		s.refreshFails[name] = err
+		s.mu.Unlock()
+		if err != nil {
+			fmt.Printf("⚠️  Trending refresh failed: %v\n", err)
+			continue
+		}
+		fmt.Printf("✅ Trending (%s) refreshed: %d titles\n", name, count)
+	}
+
+	pruned, err := s.titleRepo.PruneTitleViews(s.viewRetentionHours)
+	if err != nil {
+		fmt.Printf("⚠️  Title view pruning failed: %v\n", err)
+	} else if pruned > 0 {
+		fmt.Printf("✅ Pruned %d title views older than %d hours\n", pruned, s.viewRetentionHours)
+	}
+	fmt.Printf("✅ Trending refresh finished in %v\n", time.Since(start).Round(time.Millisecond))
+}
+
+// longestTrendingWindowHours return panjang window trending terpanjang (retensi minimal TitleViews)
+func longestTrendingWindowHours() int {
+	longest := 0
+	for _, window := range trendingWindows {
+		if window.Hours > longest {
+			longest = window.Hours
+		}
+	}
+	return longest
+}
+
+// GetTrending mengambil trending titles untuk window tertentu (day, week, month)
+// Kalau aktivitas belum cukup untuk mengisi limit (cold start), sisanya diisi dari
+// sp_getTrendings (static popularity) tanpa duplikat. Setiap title diberi TrendingSource,
+// dan fallback selalu di-log supaya ranking yang kosong / gagal refresh tidak terlihat normal
+func (s *TrendingService) GetTrending(window string, limit int) ([]*models.FilmCardData, error) {
+	if _, ok := trendingWindows[window]; !ok {
+		return nil, newValidationError("window must be one of: day, week, month")
+	}
+
+	titles, err := s.titleRepo.GetActivityTrendingTitles(window, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, title := range titles {
+		title.TrendingSource = models.TrendingSourceActivity
+	}
+	if len(titles) >= limit {
+		return titles, nil
+	}
+
+	s.mu.RLock()
+	refreshErr := s.refreshFails[window]
+	s.mu.RUnlock()
+	if refreshErr != nil {
+		fmt.Printf("⚠️  Trending (%s): %d/%d titles from activity, padding with sp_getTrendings (last refresh failed: %v)\n",
+			window, len(titles), limit, refreshErr)
+	} else {
+		fmt.Printf("⚠️  Trending (%s): %d/%d titles from activity, padding with sp_getTrendings\n",
+			window, len(titles), limit)
+	}
+
+	fallback, err := s.titleRepo.GetTrendingTitles(limit)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(titles))
+	for _, title := range titles {
+		seen[title.TitleID] = true
+	}
+	for _, title := range fallback {
+		if len(titles) >= limit {
+			break
+		}
+		if !seen[title.TitleID] {
+			title.TrendingSource = models.TrendingSourcePopularity
+			titles = append(titles, title)
+		}
+	}
+	return titles, nil
+}
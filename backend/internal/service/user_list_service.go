@@ -0,0 +1,249 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"film-dashboard-api/internal/models"
+	"film-dashboard-api/internal/repository"
+)
+
+// ErrListForbidden dikembalikan kalau user mencoba mengubah list milik user lain
+var ErrListForbidden = errors.New("you can only modify your own lists")
+
+// UserListService adalah service untuk handle user lists (koleksi title buatan user)
+type UserListService struct {
+	listRepo *repository.UserListRepository
+}
+
+// NewUserListService adalah constructor untuk bikin instance UserListService
+func NewUserListService(listRepo *repository.UserListRepository) *UserListService {
+	return &UserListService{
+		listRepo: listRepo,
+	}
+}
+
+// CreateList membuat list baru milik user
+// Business logic:
+// 1. Validate input (name wajib, visibility valid)
+// 2. Generate slug unik dari name
+// 3. Simpan via repository
+func (s *UserListService) CreateList(userID int, req models.UserListRequest) (*models.UserList, error) {
+	if err := normalizeListRequest(&req); err != nil {
+		return nil, err
+	}
+
+	slug, err := newListSlug(req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate slug: %w", err)
+	}
+
+	return s.listRepo.CreateList(userID, req.Name, req.Description, slug, req.Visibility)
+}
+
+// UpdateList update name, description, dan visibility (slug tidak berubah supaya link lama tetap valid)
+func (s *UserListService) UpdateList(userID int, listID int, req models.UserListRequest) (*models.UserList, error) {
+	if err := normalizeListRequest(&req); err != nil {
+		return nil, err
+	}
+	if _, err := s.ownedList(userID, listID); err != nil {
+		return nil, err
+	}
+
+	return s.listRepo.UpdateList(listID, req.Name, req.Description, req.Visibility)
+}
+
+// DeleteList menghapus list milik user
+func (s *UserListService) DeleteList(userID int, listID int) error {
+	if _, err := s.ownedList(userID, listID); err != nil {
+		return err
+	}
+	return s.listRepo.DeleteList(listID)
+}
+
+// GetMyLists mengambil semua list milik user (termasuk private)
+func (s *UserListService) GetMyLists(userID int) ([]*models.UserList, error) {
+	lists, err := s.listRepo.GetListsByUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lists: %w", err)
+	}
+	return lists, nil
+}
+
+// GetListBySlug mengambil list beserta entries (paged) untuk dibaca
+// viewer boleh nil (request tanpa login). List private hanya bisa dibaca owner -
+// untuk user lain dianggap tidak ada (ErrListNotFound) supaya keberadaannya tidak bocor
+func (s *UserListService) GetListBySlug(slug string, viewer *models.User, page int, limit int) (*models.UserList, []*models.UserListEntry, error) {
+	list, err := s.listRepo.GetListBySlug(slug)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if list.Visibility == models.ListVisibilityPrivate && (viewer == nil || viewer.UserID != list.UserID) {
+		return nil, nil, repository.ErrListNotFound
+	}
+
+	entries, err := s.listRepo.GetEntries(list.ListID, page, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	return list, entries, nil
+}
+
+// AddEntry menambahkan title ke akhir list
+func (s *UserListService) AddEntry(userID int, listID int, req models.UserListEntryRequest) (*models.UserList, error) {
+	if req.TitleID == "" {
+		return nil, newValidationError("title_id is required")
+	}
+	if err := validateListNote(req.Note); err != nil {
+		return nil, err
+	}
+	if _, err := s.ownedList(userID, listID); err != nil {
+		return nil, err
+	}
+
+	if err := s.listRepo.AddEntry(listID, req.TitleID, req.Note); err != nil {
+		return nil, err
+	}
+	return s.listRepo.GetListByID(listID)
+}
+
+// UpdateEntryNote update note satu entry (note nil / kosong = hapus note)
+func (s *UserListService) UpdateEntryNote(userID int, listID int, titleID string, note *string) error {
+	if err := validateListNote(note); err != nil {
+		return err
+	}
+	if _, err := s.ownedList(userID, listID); err != nil {
+		return err
+	}
+	if note != nil && strings.TrimSpace(*note) == "" {
+		note = nil
+	}
+	return s.listRepo.UpdateEntryNote(listID, titleID, note)
+}
+
+// RemoveEntry menghapus title dari list
+func (s *UserListService) RemoveEntry(userID int, listID int, titleID string) error {
+	if _, err := s.ownedList(userID, listID); err != nil {
+		return err
+	}
+	return s.listRepo.RemoveEntry(listID, titleID)
+}
+
+// ReorderEntries mengubah urutan entries
+// titleIDs harus berisi tepat semua title yang ada di list (tanpa duplikat)
+func (s *UserListService) ReorderEntries(userID int, listID int, titleIDs []string) error {
+	if _, err := s.ownedList(userID, listID); err != nil {
+		return err
+	}
+
+	current, err := s.listRepo.GetEntryTitleIDs(listID)
+	if err != nil {
+		return err
+	}
+	if len(titleIDs) != len(current) {
+		return newValidationError("title_ids must contain all %d titles in the list", len(current))
+	}
+
+	inList := make(map[string]bool, len(current))
+	for _, id := range current {
+		inList[id] = true
+	}
+	seen := make(map[string]bool, len(titleIDs))
+	for _, id := range titleIDs {
+		if !inList[id] {
+			return newValidationError("title %s is not in this list", id)
+		}
+		if seen[id] {
+			return newValidationError("title %s appears more than once", id)
+		}
+		seen[id] = true
+	}
+
+	return s.listRepo.ReorderEntries(listID, titleIDs)
+}
+
+// ownedList mengambil list dan memastikan user adalah owner-nya
+func (s *UserListService) ownedList(userID int, listID int) (*models.UserList, error) {
+	list, err := s.listRepo.GetListByID(listID)
+	if err != nil {
+		return nil, err
+	}
+	if list.UserID != userID {
+		return nil, ErrListForbidden
+	}
+	return list, nil
+}
+
+// normalizeListRequest trim input, set default visibility, dan validasi panjang field
+func normalizeListRequest(req *models.UserListRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return newValidationError("name is required")
+	}
+	if len([]rune(req.Name)) > 100 {
+		return newValidationError("name must be at most 100 characters")
+	}
+
+	if req.Description != nil {
+		description := strings.TrimSpace(*req.Description)
+		if description == "" {
+			req.Description = nil
+		} else if len([]rune(description)) > 1000 {
+			return newValidationError("description must be at most 1000 characters")
+		} else {
+			req.Description = &description
+		}
+	}
+
+	switch req.Visibility {
+	case "":
+		req.Visibility = models.ListVisibilityPrivate
+	case models.ListVisibilityPublic, models.ListVisibilityUnlisted, models.ListVisibilityPrivate:
+	default:
+		return newValidationError("visibility must be public, unlisted, or private")
+	}
+
+	return nil
+}
+
+// validateListNote memastikan note entry tidak lebih dari 1000 karakter
+func validateListNote(note *string) error {
+	if note != nil && len([]rune(*note)) > 1000 {
+		return newValidationError("note must be at most 1000 characters")
+	}
+	return nil
+}
+
+// newListSlug membuat slug URL-safe dari name + random suffix
+// contoh: "Best 90s noir" -> "best-90s-noir-3f9a1c"
+func newListSlug(name string) (string, error) {
+	var b strings.Builder
+	dash := true
+	for _, r := range strings.ToLower(name) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	base := strings.Trim(b.String(), "-")
+	if len(base) > 80 {
+		base = strings.Trim(base[:80], "-")
+	}
+	if base == "" {
+		base = "list"
+	}
+
+	suffix := make([]byte, 3)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return base + "-" + hex.EncodeToString(suffix), nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"film-dashboard-api/internal/models"
+	"film-dashboard-api/internal/repository"
+)
+
+// ViewTracker menampung detail view events di memory lalu flush batch ke TitleViews
+// Record tidak pernah menyentuh database, jadi tracking tidak menambah latency request.
+// Kalau buffer penuh (database lambat / down), event baru di-drop dan dihitung di log flush berikutnya.
+// Event yang belum di-flush hilang kalau proses berhenti (maksimal satu interval flush)
+type ViewTracker struct {
+	analyticsRepo *repository.AnalyticsRepository
+	maxBuffer     int
+
+	mu      sync.Mutex
+	buffer  []models.TitleViewEvent
+	dropped int
+	flushCh chan struct{}
+}
+
+// NewViewTracker adalah constructor untuk bikin instance ViewTracker
+// maxBuffer = jumlah event maksimal yang ditahan di memory
+func NewViewTracker(analyticsRepo *repository.AnalyticsRepository, maxBuffer int) *ViewTracker {
+	return &ViewTracker{
+		analyticsRepo: analyticsRepo,
+		maxBuffer:     maxBuffer,
+		flushCh:       make(chan struct{}, 1),
+	}
+}
+
+// Start flush buffer setiap interval, atau lebih cepat kalau buffer sudah mencapai satu batch
+func (t *ViewTracker) Start(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-t.flushCh:
+			}
+			t.flush()
+		}
+	}()
+}
+
+// Record menambahkan satu detail view ke buffer
+// userID nil = tanpa login; anonymous viewer dibedakan dari hash IP + User-Agent (tidak disimpan mentah)
+func (t *ViewTracker) Record(titleID string, userID *int, clientIP, userAgent string) {
+	event := models.TitleViewEvent{
+		TitleID:    titleID,
+		UserID:     userID,
+		VisitorKey: visitorKey(userID, clientIP, userAgent),
+		ViewedAt:   time.Now(),
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.buffer) >= t.maxBuffer {
+		t.dropped++
+		return
+	}
+	t.buffer = append(t.buffer, event)
+
+	// Trigger flush lebih awal tanpa block kalau sinyal sebelumnya belum diproses
+	if len(t.buffer)%repository.MaxTitleViewBatch == 0 {
+		select {
+		case t.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// flush mengambil semua event dari buffer lalu menyimpannya ke database
+// Kalau insert gagal, batch di-drop (bukan di-retry) supaya buffer tidak terus membesar
+func (t *ViewTracker) flush() {
+	t.mu.Lock()
+	events := t.buffer
+	dropped := t.dropped
+	t.buffer = nil
+	t.dropped = 0
+	t.mu.Unlock()
+
+	if dropped > 0 {
+		fmt.Printf("⚠️  View buffer full, dropped %d view events\n", dropped)
+	}
+	if len(events) == 0 {
+		return
+	}
+	if err := t.analyticsRepo.InsertTitleViews(events); err != nil {
+		fmt.Printf("⚠️  Failed to flush %d view events: %v\n", len(events), err)
+	}
+}
+
+// visitorKey return "u:<user_id>" untuk user yang login, "a:<sha256(ip|user agent)>" untuk anonymous
+func visitorKey(userID *int, clientIP, userAgent string) string {
+	if userID != nil {
+		return "u:" + strconv.Itoa(*userID)
+	}
+	sum := sha256.Sum256([]byte(clientIP + "|" + userAgent))
+	return "a:" + hex.EncodeToString(sum[:])[:32]
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"film-dashboard-api/internal/models"
+	"film-dashboard-api/internal/repository"
+)
+
+// watchDateLayout adalah format tanggal untuk watched_on, from, dan to ("YYYY-MM-DD")
+const watchDateLayout = "2006-01-02"
+
+// maxWatchNoteLength sama dengan panjang kolom WatchHistory.note
+const maxWatchNoteLength = 500
+
+// WatchHistoryService adalah service untuk handle watched history / viewing diary
+type WatchHistoryService struct {
+	historyRepo *repository.WatchHistoryRepository
+}
+
+// NewWatchHistoryService adalah constructor untuk bikin instance WatchHistoryService
+func NewWatchHistoryService(historyRepo *repository.WatchHistoryRepository) *WatchHistoryService {
+	return &WatchHistoryService{
+		historyRepo: historyRepo,
+	}
+}
+
+// LogWatch mencatat bahwa user menonton sebuah title
+// Business logic:
+// 1. Validate input (title_id wajib, tanggal valid & tidak di masa depan, note max 500 karakter)
+// 2. Simpan via repository (rewatch otomatis terdeteksi kalau is_rewatch tidak dikirim)
+// 3. review_id untuk title lain = validation error
+// Return repository.ErrTitleNotFound / repository.ErrReviewNotOwned sesuai kondisi
+func (s *WatchHistoryService) LogWatch(userID int, req models.WatchHistoryRequest) (*models.WatchHistoryEntry, error) {
+	titleID := strings.TrimSpace(req.TitleID)
+	if titleID == "" {
+		return nil, newValidationError("title_id is required")
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	watchedOn := today
+	if req.WatchedOn != "" {
+		parsed, err := time.Parse(watchDateLayout, req.WatchedOn)
+		if err != nil {
+			return nil, newValidationError("watched_on must be in YYYY-MM-DD format")
+		}
+		if parsed.After(today) {
+			return nil, newValidationError("watched_on cannot be in the future")
+		}
+		watchedOn = parsed
+	}
+
+	note := req.Note
+	if note != nil {
+		trimmed := strings.TrimSpace(*note)
+		if len([]rune(trimmed)) > maxWatchNoteLength {
+			return nil, newValidationError("note must be at most %d characters", maxWatchNoteLength)
+		}
+		if trimmed == "" {
+			note = nil
+		} else {
+			note = &trimmed
+		}
+	}
+
+	entry, err := s.historyRepo.LogWatch(userID, titleID, watchedOn, req.IsRewatch, req.ReviewID, note)
+	if errors.Is(err, repository.ErrReviewTitleMismatch) {
+		return nil, newValidationError("review_id must be a review of title %s", titleID)
+	}
+	return entry, err
+}
+
+// DeleteWatch menghapus satu entry diary milik user
+// Return repository.ErrWatchNotFound kalau entry tidak ada / bukan milik user
+func (s *WatchHistoryService) DeleteWatch(userID int, watchID int) error {
+	return s.historyRepo.DeleteWatch(userID, watchID)
+}
+
+// GetHistory mengambil diary user secara paged, optional dibatasi range tanggal (inclusive)
+// from & to format "YYYY-MM-DD", string kosong = tanpa batas
+// Return: entries untuk page yang diminta, total entries di range, dan error
+func (s *WatchHistoryService) GetHistory(userID int, from string, to string, page int, limit int) ([]*models.WatchHistoryEntry, int, error) {
+	fromDate, err := parseWatchDate("from", from)
+	if err != nil {
+		return nil, 0, err
+	}
+	toDate, err := parseWatchDate("to", to)
+	if err != nil {
+		return nil, 0, err
+	}
+	if fromDate != nil && toDate != nil && fromDate.After(*toDate) {
+		return nil, 0, newValidationError("from must not be after to")
+	}
+
+	entries, total, err := s.historyRepo.GetHistory(userID, fromDate, toDate, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get watch history: %w", err)
+	}
+	return entries, total, nil
+}
+
+// GetSeriesProgress mengambil episode-level progress user untuk sebuah series
+// Return repository.ErrTitleNotFound kalau series tidak punya episodes
+func (s *WatchHistoryService) GetSeriesProgress(userID int, seriesID string) (*models.SeriesProgress, error) {
+	if seriesID == "" {
+		return nil, newValidationError("series ID is required")
+	}
+
+	progress, err := s.historyRepo.GetSeriesProgress(userID, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series progress: %w", err)
+	}
+	if progress == nil {
+		return nil, repository.ErrTitleNotFound
+	}
+	return progress, nil
+}
+
+// GetYearInReview mengambil statistik tontonan user untuk satu tahun
+// year 0 = tahun berjalan
+func (s *WatchHistoryService) GetYearInReview(userID int, year int) (*models.YearInReview, error) {
+	currentYear := time.Now().Year()
+	if year == 0 {
+		year = currentYear
+	}
+	if year < 1900 || year > currentYear {
+		return nil, newValidationError("year must be between 1900 and %d", currentYear)
+	}
+
+	stats, err := s.historyRepo.GetYearInReview(userID, year)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get year in review: %w", err)
+	}
+	return stats, nil
+}
+
+// parseWatchDate parse tanggal "YYYY-MM-DD" dari query param, string kosong = nil
+func parseWatchDate(field string, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(watchDateLayout, value)
+	if err != nil {
+		return nil, newValidationError("%s must be in YYYY-MM-DD format", field)
+	}
+	return &parsed, nil
+}
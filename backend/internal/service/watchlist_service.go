@@ -0,0 +1,85 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"film-dashboard-api/internal/models"
+	"film-dashboard-api/internal/repository"
+)
+
+// WatchlistService adalah service untuk handle watchlist operations
+type WatchlistService struct {
+	watchlistRepo *repository.WatchlistRepository
+}
+
+// NewWatchlistService adalah constructor untuk bikin instance WatchlistService
+func NewWatchlistService(watchlistRepo *repository.WatchlistRepository) *WatchlistService {
+	return &WatchlistService{
+		watchlistRepo: watchlistRepo,
+	}
+}
+
+// AddToWatchlist menambahkan title ke watchlist user
+// Return repository.ErrTitleNotFound kalau title tidak ada
+func (s *WatchlistService) AddToWatchlist(userID int, req models.WatchlistRequest) (*models.WatchlistStatus, error) {
+	if req.TitleID == "" {
+		return nil, errors.New("title_id is required")
+	}
+
+	entry, err := s.watchlistRepo.AddToWatchlist(userID, req.TitleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WatchlistStatus{
+		TitleID:     entry.TitleID,
+		InWatchlist: true,
+		AddedAt:     &entry.AddedAt,
+	}, nil
+}
+
+// RemoveFromWatchlist menghapus title dari watchlist user
+// Idempotent: title yang memang tidak ada di watchlist tidak dianggap error
+func (s *WatchlistService) RemoveFromWatchlist(userID int, titleID string) (*models.WatchlistStatus, error) {
+	if titleID == "" {
+		return nil, errors.New("title_id is required")
+	}
+
+	if _, err := s.watchlistRepo.RemoveFromWatchlist(userID, titleID); err != nil {
+		return nil, err
+	}
+
+	return &models.WatchlistStatus{
+		TitleID:     titleID,
+		InWatchlist: false,
+	}, nil
+}
+
+// GetWatchlistStatus mengecek apakah title ada di watchlist user
+func (s *WatchlistService) GetWatchlistStatus(userID int, titleID string) (*models.WatchlistStatus, error) {
+	if titleID == "" {
+		return nil, errors.New("title_id is required")
+	}
+
+	entry, err := s.watchlistRepo.GetWatchlistEntry(userID, titleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watchlist status: %w", err)
+	}
+
+	status := &models.WatchlistStatus{TitleID: titleID}
+	if entry != nil {
+		status.InWatchlist = true
+		status.AddedAt = &entry.AddedAt
+	}
+	return status, nil
+}
+
+// GetWatchlist mengambil watchlist user secara paged
+func (s *WatchlistService) GetWatchlist(userID int, page int, limit int) ([]*models.WatchlistItem, int, error) {
+	items, total, err := s.watchlistRepo.GetWatchlist(userID, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get watchlist: %w", err)
+	}
+	return items, total, nil
+}
@@ -0,0 +1,31 @@
+import axiosInstance from '../utils/axios';
+
+// Type definitions (endpoint hanya untuk role di ANALYTICS_ROLES)
+export interface DailyTitleStats {
+  date: string; // YYYY-MM-DD
+  views: number;
+  unique_viewers: number;
+  reviews: number;
+}
+
+export interface TitleAnalytics {
+  title_id: string;
+  from: string;
+  to: string;
+  days: number;
+  total_views: number;
+  unique_viewers: number;
+  logged_in_viewers: number;
+  reviews: number;
+  converted_viewers: number;
+  review_conversion: number | null; // converted_viewers / logged_in_viewers
+  daily: DailyTitleStats[];
+}
+
+// API calls
+export const analyticsAPI = {
+  getTitleAnalytics: async (titleId: string, days: number = 30): Promise<TitleAnalytics> => {
+    const response = await axiosInstance.get(`/analytics/titles/${titleId}?days=${days}`);
+    return response.data.data;
+  },
+};
@@ -0,0 +1,27 @@
+import axiosInstance from '../utils/axios';
+
+// Type definitions
+export interface AutocompleteSuggestion {
+  kind: 'title' | 'person';
+  id: string;
+  name: string;
+  original_name?: string;
+  start_year?: number;
+  type?: string;
+}
+
+export interface AutocompleteResponse {
+  query: string;
+  titles: AutocompleteSuggestion[];
+  persons: AutocompleteSuggestion[];
+  indexed_at: string;
+}
+
+// API calls
+export const autocompleteAPI = {
+  // Typeahead suggestions (served dari in-memory index di backend, aman dipanggil per keystroke)
+  getSuggestions: async (query: string, limit: number = 5): Promise<AutocompleteResponse> => {
+    const response = await axiosInstance.get(`/autocomplete?q=${encodeURIComponent(query)}&limit=${limit}`);
+    return response.data.data;
+  },
+};
@@ -0,0 +1,110 @@
+import axiosInstance from '../utils/axios';
+import type { PaginationInfo } from './titles';
+
+// Type definitions
+export interface WatchHistoryRequest {
+  title_id: string;
+  watched_on?: string; // YYYY-MM-DD, default hari ini
+  is_rewatch?: boolean; // kosong = otomatis
+  review_id?: number | null;
+  note?: string | null;
+}
+
+export interface WatchHistoryEntry {
+  watch_id: number;
+  title_id: string;
+  name: string | null;
+  type: string | null;
+  start_year: number | null;
+  runtime_minutes: number | null;
+  watched_on: string;
+  is_rewatch: boolean;
+  review_id: number | null;
+  note: string | null;
+  episode: {
+    parent_title_id: string;
+    parent_name: string | null;
+    season_number: number | null;
+    episode_number: number | null;
+  } | null;
+}
+
+export interface WatchHistoryResponse {
+  from?: string;
+  to?: string;
+  entries: WatchHistoryEntry[];
+  pagination: PaginationInfo;
+}
+
+export interface SeriesProgress {
+  series_id: string;
+  total_episodes: number;
+  watched_episodes: number;
+  percent: number;
+  seasons: {
+    season_number: number | null;
+    total_episodes: number;
+    watched_episodes: number;
+  }[];
+  next_episode: {
+    title_id: string;
+    name: string | null;
+    season_number: number | null;
+    episode_number: number | null;
+    vote_average: number | null;
+    vote_count: number | null;
+    runtime_minutes: number | null;
+  } | null;
+}
+
+export interface YearInReview {
+  year: number;
+  entries: number;
+  unique_titles: number;
+  rewatches: number;
+  episodes_watched: number;
+  minutes_watched: number;
+  hours_watched: number;
+  reviews_linked: number;
+  by_month: { month: number; entries: number; hours: number }[];
+  top_genres: { genre_name: string; entries: number }[];
+}
+
+// API calls (semua butuh login)
+export const historyAPI = {
+  // Get diary user (paged, terbaru dulu), optional filter range tanggal
+  getHistory: async (
+    page: number = 1,
+    limit: number = 20,
+    from?: string,
+    to?: string
+  ): Promise<WatchHistoryResponse> => {
+    const params = new URLSearchParams({ page: String(page), limit: String(limit) });
+    if (from) params.set('from', from);
+    if (to) params.set('to', to);
+    const response = await axiosInstance.get(`/history?${params.toString()}`);
+    return response.data.data;
+  },
+
+  // Log tontonan (movie, series, atau episode)
+  logWatch: async (data: WatchHistoryRequest): Promise<WatchHistoryEntry> => {
+    const response = await axiosInstance.post(`/history`, data);
+    return response.data.data;
+  },
+
+  deleteWatch: async (watchId: number): Promise<void> => {
+    await axiosInstance.delete(`/history/${watchId}`);
+  },
+
+  // Episode progress untuk series
+  getSeriesProgress: async (seriesId: string): Promise<SeriesProgress> => {
+    const response = await axiosInstance.get(`/history/series/${seriesId}/progress`);
+    return response.data.data;
+  },
+
+  // Year-in-review (tanpa year = tahun berjalan)
+  getYearInReview: async (year?: number): Promise<YearInReview> => {
+    const response = await axiosInstance.get(year ? `/history/stats/${year}` : `/history/stats`);
+    return response.data.data;
+  },
+};
@@ -0,0 +1,83 @@
+import axiosInstance from '../utils/axios';
+import type { FilmCardData, PaginationInfo } from './titles';
+
+// Type definitions
+export type ListVisibility = 'public' | 'unlisted' | 'private';
+
+export interface UserList {
+  list_id: number;
+  user_id: number;
+  username: string;
+  name: string;
+  description: string | null;
+  slug: string;
+  visibility: ListVisibility;
+  entry_count: number;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface UserListRequest {
+  name: string;
+  description?: string | null;
+  visibility?: ListVisibility;
+}
+
+export interface UserListEntry {
+  position: number;
+  note: string | null;
+  added_at: string;
+  title: FilmCardData;
+}
+
+export interface UserListDetailResponse {
+  list: UserList;
+  entries: UserListEntry[];
+  pagination: PaginationInfo;
+}
+
+// API calls
+export const listsAPI = {
+  // Public (private list hanya bisa dibaca owner)
+  getListBySlug: async (slug: string, page: number = 1, limit: number = 50): Promise<UserListDetailResponse> => {
+    const response = await axiosInstance.get(`/lists/${slug}?page=${page}&limit=${limit}`);
+    return response.data.data;
+  },
+
+  // Protected - semua list milik user yang login
+  getMyLists: async (): Promise<UserList[]> => {
+    const response = await axiosInstance.get(`/lists`);
+    return response.data.data || [];
+  },
+
+  createList: async (data: UserListRequest): Promise<UserList> => {
+    const response = await axiosInstance.post(`/lists`, data);
+    return response.data.data;
+  },
+
+  updateList: async (listId: number, data: UserListRequest): Promise<UserList> => {
+    const response = await axiosInstance.put(`/lists/${listId}`, data);
+    return response.data.data;
+  },
+
+  deleteList: async (listId: number): Promise<void> => {
+    await axiosInstance.delete(`/lists/${listId}`);
+  },
+
+  addEntry: async (listId: number, titleId: string, note?: string): Promise<UserList> => {
+    const response = await axiosInstance.post(`/lists/${listId}/entries`, { title_id: titleId, note });
+    return response.data.data;
+  },
+
+  updateEntryNote: async (listId: number, titleId: string, note: string | null): Promise<void> => {
+    await axiosInstance.put(`/lists/${listId}/entries/${titleId}`, { note });
+  },
+
+  removeEntry: async (listId: number, titleId: string): Promise<void> => {
+    await axiosInstance.delete(`/lists/${listId}/entries/${titleId}`);
+  },
+
+  reorderEntries: async (listId: number, titleIds: string[]): Promise<void> => {
+    await axiosInstance.put(`/lists/${listId}/order`, { title_ids: titleIds });
+  },
+};
@@ -0,0 +1,95 @@
+import axiosInstance from '../utils/axios';
+import type { PaginationInfo } from './titles';
+import type { ReportReason } from './reviews';
+
+// Type definitions (semua endpoint hanya untuk moderator)
+export type ReportStatus = 'open' | 'resolved' | 'dismissed';
+
+export interface ModerationReport {
+  report_id: number;
+  review_id: number;
+  reporter_id: number;
+  reporter_username: string;
+  reason: ReportReason;
+  details: string | null;
+  status: ReportStatus;
+  created_at: string;
+  resolved_at: string | null;
+  review: {
+    review_id: number;
+    title_id: string;
+    user_id: number;
+    username: string;
+    author_is_active: boolean;
+    rating: number;
+    review_text: string;
+    is_hidden: boolean;
+    created_at: string;
+    open_reports: number;
+    author_warnings: number;
+  };
+}
+
+export interface ModerationQueueResponse {
+  status: ReportStatus | 'all';
+  reports: ModerationReport[];
+  pagination: PaginationInfo;
+}
+
+export interface ModerationAction {
+  action_id: number;
+  moderator_id: number;
+  action: string;
+  review_id: number | null;
+  report_id: number | null;
+  target_user_id: number | null;
+  reason: string | null;
+  created_at: string;
+}
+
+// API calls
+export const moderationAPI = {
+  getReports: async (
+    status: ReportStatus | 'all' = 'open',
+    page: number = 1,
+    limit: number = 20
+  ): Promise<ModerationQueueResponse> => {
+    const response = await axiosInstance.get(`/admin/moderation/reports?status=${status}&page=${page}&limit=${limit}`);
+    return response.data.data;
+  },
+
+  dismissReport: async (reportId: number, reason?: string): Promise<ModerationAction> => {
+    const response = await axiosInstance.post(`/admin/moderation/reports/${reportId}/dismiss`, { reason });
+    return response.data.data;
+  },
+
+  hideReview: async (reviewId: number, reason?: string): Promise<ModerationAction> => {
+    const response = await axiosInstance.post(`/admin/moderation/reviews/${reviewId}/hide`, { reason });
+    return response.data.data;
+  },
+
+  restoreReview: async (reviewId: number, reason?: string): Promise<ModerationAction> => {
+    const response = await axiosInstance.post(`/admin/moderation/reviews/${reviewId}/restore`, { reason });
+    return response.data.data;
+  },
+
+  deleteReview: async (reviewId: number, reason?: string): Promise<ModerationAction> => {
+    const response = await axiosInstance.delete(`/admin/moderation/reviews/${reviewId}`, { data: { reason } });
+    return response.data.data;
+  },
+
+  warnUser: async (userId: number, reason?: string): Promise<ModerationAction> => {
+    const response = await axiosInstance.post(`/admin/moderation/users/${userId}/warn`, { reason });
+    return response.data.data;
+  },
+
+  suspendUser: async (userId: number, reason?: string): Promise<ModerationAction> => {
+    const response = await axiosInstance.post(`/admin/moderation/users/${userId}/suspend`, { reason });
+    return response.data.data;
+  },
+
+  reinstateUser: async (userId: number, reason?: string): Promise<ModerationAction> => {
+    const response = await axiosInstance.post(`/admin/moderation/users/${userId}/reinstate`, { reason });
+    return response.data.data;
+  },
+};
@@ -1,4 +1,5 @@
 import axiosInstance from '../utils/axios';
+import type { PaginationInfo } from './titles';
 
 // Type definitions
 export interface Review {
@@ -10,12 +11,33 @@ export interface Review {
   review_text: string;
   created_at: string;
   updated_at: string;
+  helpful_count: number;
+  not_helpful_count: number;
+  my_vote: ReviewVote | null; // vote user yang sedang login
+  comment_count: number;
+  is_hidden: boolean; // di-hide moderator (hanya terlihat oleh author)
+  contains_spoilers: boolean;
+  spoiler_redacted: boolean; // true = review_text dikosongkan, fetch dengan spoilers=true untuk menampilkan
+  is_edited: boolean;
+  edited_at: string | null; // waktu edit terakhir
+}
+
+export type ReportReason = 'spam' | 'offensive' | 'harassment' | 'spoiler' | 'other';
+
+export type ReviewVote = 'helpful' | 'not_helpful';
+
+export interface ReviewVoteSummary {
+  review_id: number;
+  helpful_count: number;
+  not_helpful_count: number;
+  my_vote: ReviewVote | null;
 }
 
 export interface CreateReviewRequest {
   title_id: string;
   rating: number;
   review_text: string;
+  contains_spoilers?: boolean;
 }
 
 export interface ReviewResponse {
@@ -25,32 +47,103 @@ export interface ReviewResponse {
   title_id: string;
   rating: number;
   review_text: string;
+  contains_spoilers: boolean;
+  created_at: string;
+  updated_at: string;
+  is_edited: boolean;
+  edited_at: string | null;
+}
+
+export interface ReviewRevision {
+  history_id: number;
+  rating: number;
+  review_text: string;
+  contains_spoilers: boolean;
+  written_at: string;
+  replaced_at: string;
+}
+
+export interface ReviewHistoryResponse {
+  review_id: number;
+  current: Review;
+  revisions: ReviewRevision[]; // versi lama, terbaru dulu
+}
+
+export type ReviewSort = 'newest' | 'oldest' | 'highest' | 'lowest' | 'helpful';
+
+export interface RatingSummary {
+  average: number | null;
+  count: number;
+  histogram: { rating: number; count: number }[]; // selalu 10 bucket (rating 1-10)
+}
+
+export interface ReviewListResponse {
+  reviews: Review[];
+  sort: ReviewSort;
+  summary?: RatingSummary; // hanya untuk listing per title
+  pagination: PaginationInfo;
+}
+
+export interface ReviewComment {
+  comment_id: number;
+  review_id: number;
+  parent_comment_id: number | null;
+  user_id: number;
+  username: string;
+  body: string | null; // null kalau comment sudah dihapus
+  is_deleted: boolean;
+  is_edited: boolean;
+  reply_count: number;
   created_at: string;
   updated_at: string;
 }
 
+export interface ReviewCommentListResponse {
+  review_id: number;
+  parent_comment_id: number | null;
+  comments: ReviewComment[];
+  pagination: PaginationInfo;
+}
+
 // API calls
 export const reviewsAPI = {
-  // Get all reviews for a title
-  getReviews: async (titleId: string): Promise<Review[]> => {
-    const response = await axiosInstance.get(`/reviews/${titleId}`);
-    return response.data.data || [];
+  // Get reviews for a title (paged) + rating summary
+  // spoilers = false: review_text review spoiler di-redact (spoiler_redacted = true)
+  getReviews: async (
+    titleId: string,
+    page: number = 1,
+    limit: number = 20,
+    sort: ReviewSort = 'newest',
+    spoilers: boolean = false
+  ): Promise<ReviewListResponse> => {
+    const response = await axiosInstance.get(
+      `/reviews/${titleId}?page=${page}&limit=${limit}&sort=${sort}&spoilers=${spoilers}`
+    );
+    return response.data.data;
   },
 
   // Create or update review for a title
-  createReview: async (titleId: string, data: { rating: number; review_text: string }): Promise<ReviewResponse> => {
+  createReview: async (
+    titleId: string,
+    data: { rating: number; review_text: string; contains_spoilers?: boolean }
+  ): Promise<ReviewResponse> => {
     const response = await axiosInstance.post('/reviews', {
       title_id: titleId,
       rating: data.rating,
       review_text: data.review_text,
+      contains_spoilers: data.contains_spoilers ?? false,
     });
     return response.data.data;
   },
 
-  // Get all reviews written by current user
-  getUserReviews: async (): Promise<Review[]> => {
-    const response = await axiosInstance.get('/reviews/user');
-    return response.data.data || [];
+  // Get reviews written by current user (paged)
+  getUserReviews: async (
+    page: number = 1,
+    limit: number = 20,
+    sort: ReviewSort = 'newest'
+  ): Promise<ReviewListResponse> => {
+    const response = await axiosInstance.get(`/reviews/user?page=${page}&limit=${limit}&sort=${sort}`);
+    return response.data.data;
   },
 
   // Check if user has reviewed a specific title
@@ -59,8 +152,60 @@ export const reviewsAPI = {
     return response.data.data;
   },
 
+  // Edit history sebuah review (hanya author atau moderator)
+  getReviewHistory: async (reviewId: number): Promise<ReviewHistoryResponse> => {
+    const response = await axiosInstance.get(`/reviews/${reviewId}/history`);
+    return response.data.data;
+  },
+
   // Delete a review by ID
   deleteReview: async (reviewId: number): Promise<void> => {
     await axiosInstance.delete(`/reviews/${reviewId}`);
   },
+
+  // Vote review user lain sebagai helpful / not helpful (vote baru mengganti vote lama)
+  voteReview: async (reviewId: number, vote: ReviewVote): Promise<ReviewVoteSummary> => {
+    const response = await axiosInstance.put(`/reviews/${reviewId}/vote`, { vote });
+    return response.data.data;
+  },
+
+  // Hapus vote
+  removeReviewVote: async (reviewId: number): Promise<ReviewVoteSummary> => {
+    const response = await axiosInstance.delete(`/reviews/${reviewId}/vote`);
+    return response.data.data;
+  },
+
+  // Comments (tanpa parentId = top-level comments, dengan parentId = replies)
+  getComments: async (
+    reviewId: number,
+    parentId?: number,
+    page: number = 1,
+    limit: number = 20
+  ): Promise<ReviewCommentListResponse> => {
+    const parent = parentId ? `&parent=${parentId}` : '';
+    const response = await axiosInstance.get(`/reviews/${reviewId}/comments?page=${page}&limit=${limit}${parent}`);
+    return response.data.data;
+  },
+
+  createComment: async (reviewId: number, body: string, parentCommentId?: number): Promise<ReviewComment> => {
+    const response = await axiosInstance.post(`/reviews/${reviewId}/comments`, {
+      body,
+      parent_comment_id: parentCommentId ?? null,
+    });
+    return response.data.data;
+  },
+
+  updateComment: async (commentId: number, body: string): Promise<ReviewComment> => {
+    const response = await axiosInstance.put(`/reviews/comments/${commentId}`, { body });
+    return response.data.data;
+  },
+
+  deleteComment: async (commentId: number): Promise<void> => {
+    await axiosInstance.delete(`/reviews/comments/${commentId}`);
+  },
+
+  // Report review ke moderator (1 report per review)
+  reportReview: async (reviewId: number, reason: ReportReason, details?: string): Promise<void> => {
+    await axiosInstance.post(`/reviews/${reviewId}/report`, { reason, details });
+  },
 };
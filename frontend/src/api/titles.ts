@@ -7,6 +7,7 @@ export interface Title {
   vote_average: number;
   vote_count: number;
   genre_name: string;
+  trending_source?: 'activity' | 'popularity'; // hanya dari /titles/trending
 }
 
 export interface FilmCardData {
@@ -16,6 +17,7 @@ export interface FilmCardData {
   vote_average: number;
   vote_count: number;
   genre_name: string;
+  community_score: number | null; // Bayesian average imported votes + review lokal
 }
 
 // Aliases untuk backwards compatibility
@@ -23,6 +25,8 @@ export type SearchTitle = FilmCardData;
 export type TrendingTitle = FilmCardData;
 export type TopRatedTitle = FilmCardData;
 
+export type TrendingWindow = 'day' | 'week' | 'month';
+
 export interface TitleDetail {
   title_id: string | null;
   name: string | null;
@@ -39,6 +43,8 @@ export interface TitleDetail {
   type: string | null;
   status: string | null;
   tagline: string | null;
+  community_score: number | null;
+  community_review_count: number | null;
 }
 
 export interface TitleDetailResponse {
@@ -55,11 +61,33 @@ export interface TitleDetailResponse {
      episode_number: number | null;
    }>;
    cast_and_crew: Array<{
+     person_id: string | null;
      ordering: number | null;
      person_name: string | null;
      job_category: string | null;
      characters: string | null;
    }>;
+   alternate_titles: Array<{
+     title: string | null;
+     region: string | null;
+     language: string | null;
+     types: string | null;
+     attributes: string | null;
+     is_original_title: boolean | null;
+   }>;
+   links: Array<{
+     link_type_id: string | null;
+     link_type: string | null;
+     url: string | null;
+   }>;
+   parent: {
+     parent_title_id: string;
+     parent_name: string | null;
+     season_number: number | null;
+     episode_number: number | null;
+   } | null;
+   partial: boolean;
+   failed_sections: string[];
  }
 
  export interface FilteredTitle {
@@ -83,21 +111,58 @@ export interface TitleDetailResponse {
  }
 
  export interface FilterRequest {
+     q?: string | undefined;
      genreIds?: string[] | undefined;
      typeIds?: string[] | undefined;
      statusIds?: string[] | undefined;
      originCountryIds?: string[] | undefined;
      productionCountryIds?: string[] | undefined;
      year?: number | undefined;
+     yearFrom?: number | undefined;
+     yearTo?: number | undefined;
+     ratingMin?: number | undefined;
+     ratingMax?: number | undefined;
+     runtimeMin?: number | undefined;
+     runtimeMax?: number | undefined;
+     minVotes?: number | undefined;
+     adult?: boolean | undefined;
+     inProduction?: boolean | undefined;
      sortBy: string;
      page: number;
      limit: number;
+     cursor?: string | undefined;
+     includeFacets?: boolean; // facet counts hanya dihitung kalau true (filter sidebar)
    }
 
+ export interface PaginationInfo {
+   page?: number; // tidak dikirim untuk cursor page
+   limit: number;
+   total: number;
+   totalPage?: number; // tidak dikirim untuk cursor page
+   hasNext: boolean;
+   nextCursor?: string;
+ }
+
+ export interface FacetCount {
+   id: string;
+   name: string;
+   count: number;
+ }
+
+ export interface FilterFacets {
+   genres: FacetCount[];
+   types: FacetCount[];
+   statuses: FacetCount[];
+   origin_countries: FacetCount[];
+   decades: Array<{ decade: number; count: number }>;
+ }
+
  export interface FilterResponse {
    success: boolean;
    data: FilmCardData[];
    count: number;
+   pagination: PaginationInfo;
+   facets?: FilterFacets;
  }
 
 export interface FilterOption {
@@ -113,6 +178,11 @@ export interface FilterOptionsResponse {
   genres: Array<{ genre_type_id: string; genre_name: string }>;
   types: Array<{ type_id: string; type_name: string }>;
   statuses: Array<{ status_id: string; status_name: string }>;
+  origin_countries: Array<{ origin_country_type_id: string; origin_country_name: string }>;
+  production_countries: Array<{ production_country_type_id: string; production_country_name: string }>;
+  spoken_languages: Array<{ spoken_language_type_id: string; spoken_language_name: string }>;
+  networks: Array<{ network_type_id: string; network_name: string }>;
+  production_companies: Array<{ production_company_type_id: string; production_company_name: string }>;
   years: number[];
 }
 
@@ -122,8 +192,9 @@ export const titlesAPI = {
      return response.data.data;
    },
 
-   getTrendingTitles: async (limit: number = 6): Promise<Title[]> => {
-     const response = await axiosInstance.get(`/titles/trending?limit=${limit}`);
+   // Trending dari aktivitas user (reviews, watchlist adds, detail views) dalam window tertentu
+   getTrendingTitles: async (limit: number = 6, window: TrendingWindow = 'week'): Promise<Title[]> => {
+     const response = await axiosInstance.get(`/titles/trending?limit=${limit}&window=${window}`);
      return response.data.data; // Backend wrap response dalam data field
    },
 
@@ -137,6 +208,17 @@ export const titlesAPI = {
     return response.data.data;
   },
 
+  // Search dengan pagination, ranking (sortBy: relevance | popularity | rating | ...) dan filter yang sama dengan filterTitles
+  searchTitlesPaged: async (keyword: string, options: Partial<FilterRequest> = {}): Promise<FilterResponse> => {
+    const params = new URLSearchParams({ q: keyword });
+    Object.entries(options).forEach(([key, value]) => {
+      if (value === undefined || value === null || key === 'q') return;
+      params.set(key, Array.isArray(value) ? value.join(',') : String(value));
+    });
+    const response = await axiosInstance.get(`/titles/search?${params.toString()}`);
+    return response.data;
+  },
+
   getTitleDetail: async (titleId: string): Promise<TitleDetailResponse> => {
      console.log('🌐 API Call: GET /titles/${titleId}/detail');
      const response = await axiosInstance.get(`/titles/${titleId}/detail`);
@@ -0,0 +1,47 @@
+import axiosInstance from '../utils/axios';
+import type { FilmCardData, PaginationInfo } from './titles';
+
+// Type definitions
+export interface WatchlistStatus {
+  title_id: string;
+  in_watchlist: boolean;
+  added_at: string | null;
+}
+
+export interface WatchlistItem {
+  watchlist_id: number;
+  added_at: string;
+  title: FilmCardData;
+}
+
+export interface WatchlistResponse {
+  items: WatchlistItem[];
+  pagination: PaginationInfo;
+}
+
+// API calls (semua butuh login)
+export const watchlistAPI = {
+  // Get watchlist user (paged, terbaru dulu)
+  getWatchlist: async (page: number = 1, limit: number = 20): Promise<WatchlistResponse> => {
+    const response = await axiosInstance.get(`/watchlist?page=${page}&limit=${limit}`);
+    return response.data.data;
+  },
+
+  // Check apakah title ada di watchlist
+  checkWatchlistStatus: async (titleId: string): Promise<WatchlistStatus> => {
+    const response = await axiosInstance.get(`/watchlist/status/${titleId}`);
+    return response.data.data;
+  },
+
+  // Add title ke watchlist
+  addToWatchlist: async (titleId: string): Promise<WatchlistStatus> => {
+    const response = await axiosInstance.post(`/watchlist`, { title_id: titleId });
+    return response.data.data;
+  },
+
+  // Remove title dari watchlist
+  removeFromWatchlist: async (titleId: string): Promise<WatchlistStatus> => {
+    const response = await axiosInstance.delete(`/watchlist/${titleId}`);
+    return response.data.data;
+  },
+};
@@ -1,7 +1,17 @@
-import { useState, useEffect } from 'react';
-import { Trash2, Send } from 'lucide-react';
+import { useState, useEffect, useRef } from 'react';
+import { Trash2, Send, EyeOff } from 'lucide-react';
 import { useAuth } from '../context/AuthContext';
-import { reviewsAPI, Review } from '../api/reviews';
+import { reviewsAPI, Review, ReviewListResponse, ReviewSort, RatingSummary } from '../api/reviews';
+
+const PAGE_SIZE = 20;
+
+const SORT_OPTIONS: { value: ReviewSort; label: string }[] = [
+    { value: 'newest', label: 'Newest' },
+    { value: 'oldest', label: 'Oldest' },
+    { value: 'highest', label: 'Highest rating' },
+    { value: 'lowest', label: 'Lowest rating' },
+    { value: 'helpful', label: 'Most helpful' },
+];
 
 interface ReviewSectionProps {
     titleId: string;
@@ -10,32 +20,83 @@ interface ReviewSectionProps {
 export function ReviewSection({ titleId }: ReviewSectionProps) {
     const { user } = useAuth();
     const [reviews, setReviews] = useState<Review[]>([]);
+    const [summary, setSummary] = useState<RatingSummary | null>(null);
+    const [sort, setSort] = useState<ReviewSort>('newest');
+    const [hasNext, setHasNext] = useState(false);
+    const [loadingMore, setLoadingMore] = useState(false);
+    // Jumlah page yang sudah di-load ("load more"), dipakai lagi saat refetch supaya posisi tidak hilang
+    const loadedPages = useRef(1);
+    // Naikkan untuk refetch (summary & list) setelah submit / delete
+    const [reloadKey, setReloadKey] = useState(0);
     const [loading, setLoading] = useState(true);
     const [error, setError] = useState<string | null>(null);
     const [isSubmitting, setIsSubmitting] = useState(false);
+    // false = review spoiler dikirim dengan review_text kosong (spoiler_redacted), true = refetch dengan spoilers=true
+    const [showSpoilers, setShowSpoilers] = useState(false);
 
     // Form state
     const [rating, setRating] = useState(8);
     const [reviewText, setReviewText] = useState('');
+    const [containsSpoilers, setContainsSpoilers] = useState(false);
     const [hoverRating, setHoverRating] = useState(0);
 
-    // Fetch reviews
+    // Title atau sort berubah: mulai lagi dari page pertama
     useEffect(() => {
+        loadedPages.current = 1;
+    }, [titleId, sort]);
+
+    // Fetch reviews (page 1 sampai loadedPages) + rating summary dari server
+    useEffect(() => {
+        let cancelled = false;
+
         const fetchReviews = async () => {
             try {
                 setError(null);
-                const data = await reviewsAPI.getReviews(titleId);
-                setReviews(data);
+                const loaded: Review[] = [];
+                let data: ReviewListResponse | null = null;
+                for (let page = 1; page <= loadedPages.current; page++) {
+                    data = await reviewsAPI.getReviews(titleId, page, PAGE_SIZE, sort, showSpoilers);
+                    loaded.push(...data.reviews);
+                    if (!data.pagination.hasNext) break;
+                }
+                if (cancelled || !data) return;
+                setReviews(loaded);
+                setHasNext(data.pagination.hasNext);
+                setSummary(data.summary ?? null);
             } catch (err) {
                 console.error('Failed to fetch reviews:', err);
-                setError('Failed to load reviews');
+                if (!cancelled) setError('Failed to load reviews');
             } finally {
-                setLoading(false);
+                if (!cancelled) setLoading(false);
             }
         };
 
         fetchReviews();
-    }, [titleId]);
+        return () => {
+            cancelled = true;
+        };
+    }, [titleId, sort, showSpoilers, reloadKey]);
+
+    // Load page berikutnya (pagination.hasNext)
+    const handleLoadMore = async () => {
+        setLoadingMore(true);
+        try {
+            setError(null);
+            const nextPage = loadedPages.current + 1;
+            const data = await reviewsAPI.getReviews(titleId, nextPage, PAGE_SIZE, sort, showSpoilers);
+            loadedPages.current = nextPage;
+            setReviews((prev) => [
+                ...prev,
+                ...data.reviews.filter((r) => !prev.some((p) => p.review_id === r.review_id)),
+            ]);
+            setHasNext(data.pagination.hasNext);
+        } catch (err) {
+            console.error('Failed to load more reviews:', err);
+            setError('Failed to load more reviews');
+        } finally {
+            setLoadingMore(false);
+        }
+    };
 
     // Handle submit review
     const handleSubmitReview = async (e: React.FormEvent) => {
@@ -57,6 +118,7 @@ export function ReviewSection({ titleId }: ReviewSectionProps) {
             const newReview = await reviewsAPI.createReview(titleId, {
                 rating,
                 review_text: reviewText,
+                contains_spoilers: containsSpoilers,
             });
 
             // Update review list - if user already had a review, replace it; otherwise add new
@@ -64,12 +126,17 @@ export function ReviewSection({ titleId }: ReviewSectionProps) {
             if (existingReviewIndex >= 0) {
                 const updatedReviews = [...reviews];
                 updatedReviews[existingReviewIndex] = {
+                    ...updatedReviews[existingReviewIndex],
                     review_id: newReview.review_id,
                     user_id: user.user_id,
                     username: user.username,
                     title_id: titleId,
                     rating: newReview.rating,
                     review_text: newReview.review_text,
+                    contains_spoilers: newReview.contains_spoilers,
+                    spoiler_redacted: false,
+                    is_edited: newReview.is_edited,
+                    edited_at: newReview.edited_at,
                     created_at: newReview.created_at,
                     updated_at: newReview.updated_at,
                 };
@@ -85,6 +152,15 @@ export function ReviewSection({ titleId }: ReviewSectionProps) {
                         review_text: newReview.review_text,
                         created_at: newReview.created_at,
                         updated_at: newReview.updated_at,
+                        helpful_count: 0,
+                        not_helpful_count: 0,
+                        my_vote: null,
+                        comment_count: 0,
+                        is_hidden: false,
+                        contains_spoilers: newReview.contains_spoilers,
+                        spoiler_redacted: false,
+                        is_edited: newReview.is_edited,
+                        edited_at: newReview.edited_at,
                     },
                     ...reviews,
                 ]);
@@ -93,6 +169,8 @@ export function ReviewSection({ titleId }: ReviewSectionProps) {
             // Clear form
             setRating(8);
             setReviewText('');
+            setContainsSpoilers(false);
+            setReloadKey((k) => k + 1); // Sinkronkan summary & urutan dengan server
         } catch (err) {
             console.error('Failed to submit review:', err);
             setError('Failed to submit review.');
@@ -111,6 +189,7 @@ export function ReviewSection({ titleId }: ReviewSectionProps) {
             setError(null);
             await reviewsAPI.deleteReview(reviewId);
             setReviews(reviews.filter(r => r.review_id !== reviewId));
+            setReloadKey((k) => k + 1);
         } catch (err) {
             console.error('Failed to delete review:', err);
             setError('Failed to delete review');
@@ -119,7 +198,50 @@ export function ReviewSection({ titleId }: ReviewSectionProps) {
 
     return (
         <section className="mb-16">
-            <h2 className="text-light text-2xl font-bold mb-8">Reviews</h2>
+            <div className="flex flex-wrap items-center justify-between gap-4 mb-8">
+                <h2 className="text-light text-2xl font-bold">Reviews</h2>
+                <select
+                    value={sort}
+                    onChange={(e) => setSort(e.target.value as ReviewSort)}
+                    className="bg-secondary border-2 border-gray-600 text-light rounded-lg px-3 py-2 focus:border-accent focus:outline-none"
+                >
+                    {SORT_OPTIONS.map((option) => (
+                        <option key={option.value} value={option.value}>
+                            {option.label}
+                        </option>
+                    ))}
+                </select>
+            </div>
+
+            {/* Rating Summary (average + histogram dari server) */}
+            {summary && summary.count > 0 && (
+                <div className="bg-secondary border border-gray-600 rounded-lg p-6 mb-8 flex flex-col sm:flex-row gap-6">
+                    <div className="text-center sm:w-40">
+                        <p className="text-accent text-4xl font-bold">
+                            {summary.average !== null ? summary.average.toFixed(1) : '-'}
+                        </p>
+                        <p className="text-gray-400 text-sm">
+                            {summary.count} {summary.count === 1 ? 'review' : 'reviews'}
+                        </p>
+                    </div>
+                    <div className="flex-1 space-y-1">
+                        {[...summary.histogram]
+                            .sort((a, b) => b.rating - a.rating)
+                            .map((bucket) => (
+                                <div key={bucket.rating} className="flex items-center gap-2 text-sm">
+                                    <span className="w-6 text-right text-gray-400">{bucket.rating}</span>
+                                    <div className="flex-1 h-2 bg-primary rounded">
+                                        <div
+                                            className="h-2 bg-accent rounded"
+                                            style={{ width: `${(bucket.count / summary.count) * 100}%` }}
+                                        />
+                                    </div>
+                                    <span className="w-8 text-gray-400">{bucket.count}</span>
+                                </div>
+                            ))}
+                    </div>
+                </div>
+            )}
 
             {error && (
                 <div className="bg-red-500/10 border-2 border-red-500 text-red-500 px-4 py-3 rounded-lg mb-6">
@@ -171,6 +293,15 @@ export function ReviewSection({ titleId }: ReviewSectionProps) {
                             className="w-full bg-primary border-2 border-gray-600 text-light rounded-lg p-4 focus:border-accent focus:outline-none transition-colors resize-none"
                             rows={4}
                         />
+                        <label className="flex items-center gap-2 mt-3 text-gray-400 text-sm cursor-pointer">
+                            <input
+                                type="checkbox"
+                                checked={containsSpoilers}
+                                onChange={(e) => setContainsSpoilers(e.target.checked)}
+                                className="accent-accent"
+                            />
+                            This review contains spoilers
+                        </label>
                     </div>
 
                     {/* Submit Button */}
@@ -226,13 +357,39 @@ export function ReviewSection({ titleId }: ReviewSectionProps) {
                                 )}
                             </div>
 
-                            <p className="text-light mb-3">
-                                {review.review_text}
-                            </p>
+                            {review.spoiler_redacted ? (
+                                <div className="flex items-center justify-between gap-3 bg-primary border border-dashed border-gray-600 rounded-lg px-4 py-3 mb-3">
+                                    <span className="flex items-center gap-2 text-gray-400">
+                                        <EyeOff size={18} />
+                                        This review contains spoilers
+                                    </span>
+                                    <button
+                                        type="button"
+                                        onClick={() => setShowSpoilers(true)}
+                                        className="text-accent text-sm font-semibold hover:underline"
+                                    >
+                                        Show spoilers
+                                    </button>
+                                </div>
+                            ) : (
+                                <p className="text-light mb-3">
+                                    {review.contains_spoilers && (
+                                        <span className="inline-block mr-2 px-2 py-0.5 text-xs font-semibold rounded bg-red-500/20 text-red-400">
+                                            Spoiler
+                                        </span>
+                                    )}
+                                    {review.review_text}
+                                </p>
+                            )}
 
                             <p className="text-gray-400 text-sm">
                                 {new Date(review.created_at).toLocaleDateString()} •{' '}
                                 {new Date(review.created_at).toLocaleTimeString()}
+                                {review.is_edited && review.edited_at && (
+                                    <span title={new Date(review.edited_at).toLocaleString()}>
+                                        {' '}• edited {new Date(review.edited_at).toLocaleDateString()}
+                                    </span>
+                                )}
                             </p>
                         </div>
                     ))
@@ -243,6 +400,20 @@ export function ReviewSection({ titleId }: ReviewSectionProps) {
                         </p>
                     </div>
                 )}
+
+                {/* Load More */}
+                {!loading && hasNext && (
+                    <div className="text-center pt-2">
+                        <button
+                            type="button"
+                            onClick={handleLoadMore}
+                            disabled={loadingMore}
+                            className="px-6 py-2 border-2 border-accent text-accent font-semibold rounded-lg hover:bg-accent hover:text-primary transition-colors disabled:opacity-50 disabled:cursor-not-allowed"
+                        >
+                            {loadingMore ? 'Loading...' : 'Load more reviews'}
+                        </button>
+                    </div>
+                )}
             </div>
         </section>
     );
@@ -10,6 +10,7 @@ const SORT_OPTIONS = [
     { id: 'popularity', label: 'Most Viewed' },
     { id: 'name', label: 'Name' },
     { id: 'rating', label: 'IMDb Rating' },
+    { id: 'community', label: 'Community Score' },
 ];
 
 export function FilterSearchPage() {